@@ -0,0 +1,277 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AccessEntry is the effective access of a subject to an object and action.
+type AccessEntry struct {
+	Subject string `json:"subject"`
+	// Domain is the domain of the granting rules, for models having a "dom" field.
+	Domain string `json:"domain,omitempty"`
+	Object string `json:"object"`
+	Action string `json:"action"`
+	// Direct is set if a rule grants the access to the subject itself.
+	Direct bool `json:"direct"`
+	// Via lists the roles of the subject that are granted the access, sorted.
+	Via []string `json:"via,omitempty"`
+}
+
+// Inherited reports whether the access is granted through a role.
+func (a AccessEntry) Inherited() bool {
+	return len(a.Via) > 0
+}
+
+// column returns the column of the entry in the HTML table.
+func (a AccessEntry) column() string {
+	if a.Domain != "" {
+		return a.Domain + ": " + a.Object + " " + a.Action
+	}
+	return a.Object + " " + a.Action
+}
+
+// AccessMatrixOptions filters the entries of an access matrix.
+type AccessMatrixOptions struct {
+	// ObjectPrefix keeps the entries whose object starts with the prefix.
+	ObjectPrefix string
+	// Role keeps the subjects holding the role, directly or through other roles.
+	// With a domain model, it keeps the domains in which they hold it.
+	Role string
+}
+
+// AccessMatrix is the effective access of every subject, for access reviews.
+type AccessMatrix struct {
+	// Entries are sorted by subject, domain, object and action.
+	Entries []AccessEntry `json:"entries"`
+}
+
+// AccessMatrix builds the effective access of every subject of the policy, i.e. the subjects returned
+// by GetAllSubjects and the users of "g" rules, from their implicit permissions,
+// and tells whether each access is granted directly or through roles.
+// With a model whose policy has a "dom" field, the access is computed in each domain of the policy,
+// following the role inheritance rules of that domain.
+// It makes two requests per subject, and per domain with a domain model.
+func (e *Enforcer) AccessMatrix(ctx context.Context, opts AccessMatrixOptions) (*AccessMatrix, error) {
+	subjects, err := e.GetAllSubjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	grouping, err := e.GetGroupingPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, subject := range append(subjects, uniqueField(grouping, 0)...) {
+		seen[subject] = true
+	}
+
+	// A nil domain stands for the calls without domain.
+	domains := []*string{nil}
+	if e.model != nil {
+		if index, ok := e.fieldIndex("p", "dom"); ok {
+			policy, err := e.GetPolicy(ctx)
+			if err != nil {
+				return nil, err
+			}
+			values := make(map[string]bool)
+			for _, domain := range append(uniqueField(grouping, domainIndex), uniqueField(policy, index)...) {
+				values[domain] = true
+			}
+			domains = domains[:0]
+			for _, domain := range sortedKeys(values) {
+				domain := domain
+				domains = append(domains, &domain)
+			}
+		}
+	}
+
+	m := &AccessMatrix{Entries: []AccessEntry{}}
+	for _, subject := range sortedKeys(seen) {
+		var permissions [][]string
+		for _, domain := range domains {
+			var dom []string
+			if domain != nil {
+				dom = []string{*domain}
+			}
+			if opts.Role != "" {
+				roles, err := e.GetImplicitRolesForUser(ctx, subject, dom...)
+				if err != nil {
+					return nil, err
+				}
+				if !containsString(roles, opts.Role) {
+					continue
+				}
+			}
+			granted, err := e.GetImplicitPermissionsForUser(ctx, subject, dom...)
+			if err != nil {
+				return nil, err
+			}
+			permissions = append(permissions, granted...)
+		}
+		m.Entries = append(m.Entries, e.accessEntries(subject, permissions, opts)...)
+	}
+	return m, nil
+}
+
+// accessEntries merges the permissions of subject into one entry per domain, object and action.
+func (e *Enforcer) accessEntries(subject string, permissions [][]string, opts AccessMatrixOptions) []AccessEntry {
+	byKey := make(map[string]*AccessEntry)
+	for _, permission := range permissions {
+		if len(permission) == 0 {
+			continue
+		}
+		rule := e.newRule("p", permission)
+		if !strings.HasPrefix(rule.Obj(), opts.ObjectPrefix) {
+			continue
+		}
+		key := rule.Dom() + "\x00" + rule.Obj() + "\x00" + rule.Act()
+		entry, ok := byKey[key]
+		if !ok {
+			entry = &AccessEntry{Subject: subject, Domain: rule.Dom(), Object: rule.Obj(), Action: rule.Act()}
+			byKey[key] = entry
+		}
+		if grantee := permission[0]; grantee == subject {
+			entry.Direct = true
+		} else if !containsString(entry.Via, grantee) {
+			entry.Via = append(entry.Via, grantee)
+		}
+	}
+
+	entries := make([]AccessEntry, 0, len(byKey))
+	for _, entry := range byKey {
+		sort.Strings(entry.Via)
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Domain != b.Domain {
+			return a.Domain < b.Domain
+		}
+		if a.Object != b.Object {
+			return a.Object < b.Object
+		}
+		return a.Action < b.Action
+	})
+	return entries
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteJSON writes the matrix to w as indented JSON.
+func (m *AccessMatrix) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(m)
+}
+
+// WriteCSV writes the matrix to w as CSV, one entry per line, with a header line and the columns
+// subject, domain, object, action, direct and via, the roles of via being separated by ";".
+func (m *AccessMatrix) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"subject", "domain", "object", "action", "direct", "via"}); err != nil {
+		return err
+	}
+	for _, entry := range m.Entries {
+		record := []string{entry.Subject, entry.Domain, entry.Object, entry.Action,
+			strconv.FormatBool(entry.Direct), strings.Join(entry.Via, ";")}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+var accessMatrixTemplate = template.Must(template.New("matrix").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Access matrix</title>
+<style>
+table { border-collapse: collapse; font-family: sans-serif; font-size: 13px; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { background: #f4f4f4; }
+td.direct { background: #d8f0d8; }
+td.inherited { background: #fdf2cc; }
+</style>
+</head>
+<body>
+<table>
+<tr><th>Subject</th>{{range .Columns}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr><th>{{.Subject}}</th>{{range .Cells}}<td{{if .Class}} class="{{.Class}}"{{end}}>{{.Text}}</td>{{end}}</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+type accessCell struct {
+	Class, Text string
+}
+
+type accessRow struct {
+	Subject string
+	Cells   []accessCell
+}
+
+// WriteHTML writes the matrix to w as an HTML page holding a table with a row per subject
+// and a column per domain, object and action. Cells show "direct" and the roles granting the access,
+// direct access being highlighted in green and inherited access in yellow.
+func (m *AccessMatrix) WriteHTML(w io.Writer) error {
+	seen := make(map[string]bool)
+	for _, entry := range m.Entries {
+		seen[entry.column()] = true
+	}
+	columns := sortedKeys(seen)
+	columnIndex := make(map[string]int, len(columns))
+	for i, column := range columns {
+		columnIndex[column] = i
+	}
+
+	var rows []accessRow
+	for _, entry := range m.Entries {
+		if len(rows) == 0 || rows[len(rows)-1].Subject != entry.Subject {
+			rows = append(rows, accessRow{Subject: entry.Subject, Cells: make([]accessCell, len(columns))})
+		}
+		cell := accessCell{Class: "inherited"}
+		var text []string
+		if entry.Direct {
+			cell.Class = "direct"
+			text = append(text, "direct")
+		}
+		text = append(text, entry.Via...)
+		cell.Text = strings.Join(text, ", ")
+		rows[len(rows)-1].Cells[columnIndex[entry.column()]] = cell
+	}
+	return accessMatrixTemplate.Execute(w, struct {
+		Columns []string
+		Rows    []accessRow
+	}{columns, rows})
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAccessMatrix(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("p", "alice", "data1", "read"),
+		NewRule("p", "alice", "data2", "read"),
+		NewRule("p", "data2_admin", "data2", "read"),
+		NewRule("p", "data2_admin", "data2", "write"),
+		NewRule("p", "bob", "report", "read"),
+		NewRule("g", "alice", "data2_admin"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+
+	m, err := e.AccessMatrix(ctx, AccessMatrixOptions{ObjectPrefix: "data", Role: "data2_admin"})
+	if err != nil {
+		t.Fatalf("AccessMatrix err: %v", err)
+	}
+	var csv bytes.Buffer
+	if err := m.WriteCSV(&csv); err != nil {
+		t.Fatalf("WriteCSV err: %v", err)
+	}
+	want := `subject,domain,object,action,direct,via
+alice,,data1,read,true,
+alice,,data2,read,true,data2_admin
+alice,,data2,write,false,data2_admin
+`
+	if csv.String() != want {
+		t.Errorf("WriteCSV:\n%s\nsupposed to be:\n%s", csv.String(), want)
+	}
+
+	var html bytes.Buffer
+	if err := m.WriteHTML(&html); err != nil {
+		t.Fatalf("WriteHTML err: %v", err)
+	}
+	if !strings.Contains(html.String(), `<tr><th>alice</th><td class="direct">direct</td><td class="direct">direct, data2_admin</td><td class="inherited">data2_admin</td></tr>`) {
+		t.Errorf("WriteHTML:\n%s", html.String())
+	}
+}
+
+func TestAccessMatrixWithDomains(t *testing.T) {
+	e := newTestEnforcer(t, rbacWithDomainsModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("p", "admin", "domain1", "data1", "read"),
+		NewRule("p", "admin", "domain2", "data2", "read"),
+		NewRule("g", "alice", "admin", "domain1"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+	if ok, err := e.Enforce(ctx, "alice", "domain1", "data1", "read"); !ok || err != nil {
+		t.Fatalf("Enforce = %v, %v", ok, err)
+	}
+
+	m, err := e.AccessMatrix(ctx, AccessMatrixOptions{})
+	if err != nil {
+		t.Fatalf("AccessMatrix err: %v", err)
+	}
+	var csv bytes.Buffer
+	if err := m.WriteCSV(&csv); err != nil {
+		t.Fatalf("WriteCSV err: %v", err)
+	}
+	want := `subject,domain,object,action,direct,via
+admin,domain1,data1,read,true,
+admin,domain2,data2,read,true,
+alice,domain1,data1,read,false,admin
+`
+	if csv.String() != want {
+		t.Errorf("WriteCSV:\n%s\nsupposed to be:\n%s", csv.String(), want)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// ApplyOptions controls how Enforcer.Apply reconciles the policy.
+type ApplyOptions struct {
+	// Prune removes the rules that exist on the server but are missing from the desired state.
+	Prune bool
+	// DryRun computes the changes without sending them to the server.
+	DryRun bool
+	// PTypes restricts reconciliation to the given ptypes.
+	// By default, all ptypes of the model and of the desired rules are reconciled.
+	PTypes []string
+}
+
+// ApplyReport describes the changes made, or planned in dry-run mode, by Enforcer.Apply.
+type ApplyReport struct {
+	// Added lists the rules added to the server, in the order they were sent.
+	Added []Rule
+	// Removed lists the rules removed from the server, in the order they were sent.
+	Removed []Rule
+	// Unchanged is the number of desired rules already present on the server.
+	Unchanged int
+	// DryRun is set when nothing was sent to the server.
+	DryRun bool
+}
+
+// Apply makes the server policy converge to the desired rules.
+// It fetches the current rules of every reconciled ptype, adds the desired rules that are missing and,
+// with opts.Prune, removes the rules that are not desired.
+//
+// Changes are sent in an order that keeps every intermediate policy from granting access that neither
+// the current nor the desired policy grants: rules with a "deny" effect are added first, then role inheritance
+// rules and the other policy rules are removed, the other policy rules and role inheritance rules are added,
+// and rules with a "deny" effect are removed last. This holds as long as role inheritance rules only extend
+// access: removing a role inheritance rule to a role holding "deny" rules lifts them at once.
+// The changes are applied with Tx, so a failure reverts the changes made so far.
+func (e *Enforcer) Apply(ctx context.Context, desired []Rule, opts ApplyOptions) (*ApplyReport, error) {
+	ptypes := opts.PTypes
+	if len(ptypes) == 0 {
+		ptypes = mergePTypes(e.ptypes(), desired)
+	}
+	inScope := make(map[string]bool, len(ptypes))
+	for _, ptype := range ptypes {
+		inScope[ptype] = true
+	}
+	for _, rule := range desired {
+		if !inScope[rule.PType] {
+			return nil, fmt.Errorf("desired rule %q has a ptype outside of %v", rule.String(), ptypes)
+		}
+	}
+
+	current, err := e.getRules(ctx, ptypes)
+	if err != nil {
+		return nil, err
+	}
+	added, removed, unchanged := diffRules(current, desired, opts.Prune)
+	report := &ApplyReport{Unchanged: unchanged, DryRun: opts.DryRun}
+
+	ops := e.applyOps(added, removed)
+
+	if opts.DryRun {
+		for _, op := range ops {
+			report.addOp(e, op)
+		}
+		return report, nil
+	}
+
+	txReport, err := e.Tx(ctx, func(tx *PolicyTx) error {
+		for _, op := range ops {
+			tx.record(op.Action, op.PType, op.Rule)
+		}
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+	for _, op := range txReport.Applied {
+		if op.Affected {
+			report.addOp(e, op)
+		}
+	}
+	return report, nil
+}
+
+// applyOps orders the changes of Apply.
+func (e *Enforcer) applyOps(added, removed []Rule) []TxOp {
+	isDeny := func(rule Rule) bool {
+		eft, _ := e.newRule(rule.PType, rule.Values).Field("eft")
+		return !rule.IsGrouping() && eft == "deny"
+	}
+	isAllow := func(rule Rule) bool {
+		return !rule.IsGrouping() && !isDeny(rule)
+	}
+
+	ops := make([]TxOp, 0, len(added)+len(removed))
+	for _, step := range []struct {
+		action TxAction
+		rules  []Rule
+		match  func(Rule) bool
+	}{
+		{TxAdd, added, isDeny},
+		{TxRemove, removed, Rule.IsGrouping},
+		{TxRemove, removed, isAllow},
+		{TxAdd, added, isAllow},
+		{TxAdd, added, Rule.IsGrouping},
+		{TxRemove, removed, isDeny},
+	} {
+		for _, rule := range step.rules {
+			if step.match(rule) {
+				ops = append(ops, TxOp{Action: step.action, PType: rule.PType, Rule: rule.Values})
+			}
+		}
+	}
+	return ops
+}
+
+func (r *ApplyReport) addOp(e *Enforcer, op TxOp) {
+	rule := e.newRule(op.PType, op.Rule)
+	if op.Action == TxAdd {
+		r.Added = append(r.Added, rule)
+	} else {
+		r.Removed = append(r.Removed, rule)
+	}
+}
+
+// diffRules returns the desired rules missing from current, the current rules missing from desired
+// (only if prune is set), and the number of desired rules present in current.
+// Duplicated desired rules are counted once.
+func diffRules(current, desired []Rule, prune bool) (added, removed []Rule, unchanged int) {
+	currentSet := make(map[string]bool, len(current))
+	for _, rule := range current {
+		currentSet[rule.key()] = true
+	}
+	desiredSet := make(map[string]bool, len(desired))
+	for _, rule := range desired {
+		if desiredSet[rule.key()] {
+			continue
+		}
+		desiredSet[rule.key()] = true
+		if currentSet[rule.key()] {
+			unchanged++
+		} else {
+			added = append(added, rule)
+		}
+	}
+	if prune {
+		for _, rule := range current {
+			if !desiredSet[rule.key()] {
+				removed = append(removed, rule)
+			}
+		}
+	}
+	return added, removed, unchanged
+}
+
+// mergePTypes appends the ptypes of rules that are missing from ptypes.
+func mergePTypes(ptypes []string, rules []Rule) []string {
+	seen := make(map[string]bool, len(ptypes))
+	for _, ptype := range ptypes {
+		seen[ptype] = true
+	}
+	for _, rule := range rules {
+		if !seen[rule.PType] {
+			seen[rule.PType] = true
+			ptypes = append(ptypes, rule.PType)
+		}
+	}
+	return ptypes
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApply(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddPolicy(ctx, "bob", "data2", "write"); err != nil {
+		t.Fatalf("AddPolicy err: %v", err)
+	}
+	desired := []Rule{
+		NewRule("p", "alice", "data1", "read"),
+		NewRule("p", "data2_admin", "data2", "read"),
+		NewRule("g", "alice", "data2_admin"),
+	}
+
+	report, err := e.Apply(ctx, desired, ApplyOptions{Prune: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("Apply err: %v", err)
+	}
+	if len(report.Added) != 3 || len(report.Removed) != 1 || !report.DryRun {
+		t.Errorf("dry-run report: %+v", report)
+	}
+	policies, err := e.GetPolicy(ctx)
+	if err != nil {
+		t.Fatalf("GetPolicy err: %v", err)
+	}
+	testGetPolicy(t, policies, [][]string{{"bob", "data2", "write"}})
+
+	report, err = e.Apply(ctx, desired, ApplyOptions{})
+	if err != nil {
+		t.Fatalf("Apply err: %v", err)
+	}
+	if len(report.Added) != 3 || len(report.Removed) != 0 || report.Added[2].PType != "g" {
+		t.Errorf("report: %+v", report)
+	}
+
+	report, err = e.Apply(ctx, desired, ApplyOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("Apply err: %v", err)
+	}
+	if len(report.Added) != 0 || len(report.Removed) != 1 || report.Unchanged != 3 {
+		t.Errorf("report: %+v", report)
+	}
+	policies, err = e.GetPolicy(ctx)
+	if err != nil {
+		t.Fatalf("GetPolicy err: %v", err)
+	}
+	testGetPolicy(t, policies, [][]string{{"alice", "data1", "read"}, {"data2_admin", "data2", "read"}})
+}
+
+func TestApplyDenyOrder(t *testing.T) {
+	e := newTestEnforcer(t, rbacWithDenyModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// bob is denied data1 although his role allows it; the desired policy drops both rules
+	// and denies carol instead, who gains the role.
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("p", "reader", "data1", "read", "allow"),
+		NewRule("p", "bob", "data1", "read", "deny"),
+		NewRule("g", "bob", "reader"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+	desired := []Rule{
+		NewRule("p", "reader", "data2", "read", "allow"),
+		NewRule("p", "carol", "data2", "read", "deny"),
+		NewRule("g", "bob", "reader"),
+		NewRule("g", "carol", "reader"),
+	}
+
+	current, err := e.getRules(ctx, e.ptypes())
+	if err != nil {
+		t.Fatalf("getRules err: %v", err)
+	}
+	added, removed, _ := diffRules(current, desired, true)
+	var order []string
+	for _, op := range e.applyOps(added, removed) {
+		order = append(order, op.String())
+	}
+	want := []string{
+		"add p, carol, data2, read, deny",
+		"remove p, reader, data1, read, allow",
+		"add p, reader, data2, read, allow",
+		"add g, carol, reader",
+		"remove p, bob, data1, read, deny",
+	}
+	if strings.Join(order, "\n") != strings.Join(want, "\n") {
+		t.Errorf("applyOps = %q, supposed to be %q", order, want)
+	}
+
+	if _, err := e.Apply(ctx, desired, ApplyOptions{Prune: true}); err != nil {
+		t.Fatalf("Apply err: %v", err)
+	}
+	for _, user := range []string{"bob", "carol"} {
+		for _, obj := range []string{"data1", "data2"} {
+			allowed, err := e.Enforce(ctx, user, obj, "read")
+			if want := user == "bob" && obj == "data2"; err != nil || allowed != want {
+				t.Errorf("Enforce(%s, %s, read) = %v, %v", user, obj, allowed, err)
+			}
+		}
+	}
+}
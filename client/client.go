@@ -41,3 +41,10 @@ func NewClient(ctx context.Context, address string, opts ...grpc.DialOption) (*C
 		remoteClient: c,
 	}, nil
 }
+
+// WithMaxRecvMsgSize returns a dial option that raises the maximum size of the messages the client accepts,
+// which is 4 MB by default in gRPC. Use it when fetching large policies in one call; the messages of a
+// PolicyIterator only hold the rules of one key instead.
+func WithMaxRecvMsgSize(bytes int) grpc.DialOption {
+	return grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(bytes))
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// CloneRoleOptions controls what Enforcer.CloneRole copies besides the permissions.
+type CloneRoleOptions struct {
+	// Parents also copies the roles inherited by the source role.
+	Parents bool
+	// Members also copies the users and roles holding the source role.
+	Members bool
+}
+
+// CloneRole gives role dst the permissions of role src, i.e. a copy of every authorization rule of src
+// with dst as subject, in every policy ptype of the model. With opts, the "g" rules linking src to its
+// parent roles and members are copied too. It returns the rules added, which excludes those dst already had.
+// The rules are added with Tx, so a failure reverts the rules added so far.
+// src and dst must be different and not empty, as an empty filter value would match every rule.
+func (e *Enforcer) CloneRole(ctx context.Context, src, dst string, opts CloneRoleOptions) ([]Rule, error) {
+	if src == "" || dst == "" {
+		return nil, fmt.Errorf("cannot clone role %q into %q, the role names must not be empty", src, dst)
+	}
+	if src == dst {
+		return nil, fmt.Errorf("cannot clone role %q into itself", src)
+	}
+
+	var rules []Rule
+	for _, ptype := range e.ptypes() {
+		if isGroupingPType(ptype) {
+			continue
+		}
+		index, ok := e.fieldIndex(ptype, "sub")
+		if !ok {
+			index = 0
+		}
+		permissions, err := e.GetFilteredNamedPolicyRules(ctx, ptype, int32(index), src)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range permissions {
+			rules = append(rules, replaceField(rule, index, dst))
+		}
+	}
+
+	if opts.Parents {
+		parents, err := e.GetFilteredGroupingPolicyRules(ctx, 0, src)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range parents {
+			rules = append(rules, replaceField(rule, 0, dst))
+		}
+	}
+	if opts.Members {
+		members, err := e.GetFilteredGroupingPolicyRules(ctx, 1, src)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range members {
+			rules = append(rules, replaceField(rule, 1, dst))
+		}
+	}
+	return e.addRulesTx(ctx, rules)
+}
+
+// replaceField returns a copy of rule with field index set to value.
+func replaceField(rule Rule, index int, value string) Rule {
+	values := append([]string(nil), rule.Values...)
+	values[index] = value
+	rule.Values = values
+	return rule
+}
+
+// addRulesTx adds rules with Tx and returns those that did not exist.
+func (e *Enforcer) addRulesTx(ctx context.Context, rules []Rule) ([]Rule, error) {
+	report, err := e.Tx(ctx, func(tx *PolicyTx) error {
+		for _, rule := range rules {
+			tx.record(TxAdd, rule.PType, rule.Values)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	added := []Rule{}
+	for _, op := range report.Applied {
+		if op.Affected {
+			added = append(added, e.newRule(op.PType, op.Rule))
+		}
+	}
+	return added, nil
+}
+
+var placeholderPattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// RoleTemplate describes a role whose name, permissions and parent roles contain placeholders
+// such as "{tenant}", substituted when the template is instantiated:
+//
+//	tmpl := client.RoleTemplate{
+//		Name:        "{tenant}_admin",
+//		Permissions: [][]string{{"/tenants/{tenant}/*", "write"}},
+//		Parents:     []string{"{tenant}_reader"},
+//	}
+//	rules, err := e.InstantiateRoleTemplate(ctx, tmpl, map[string]string{"tenant": "acme"})
+type RoleTemplate struct {
+	// Name is the name of the role.
+	Name string `json:"name" yaml:"name"`
+	// PType is the ptype of the permissions, "p" by default.
+	PType string `json:"ptype,omitempty" yaml:"ptype,omitempty"`
+	// Permissions are the fields of the authorization rules of the role, after the subject.
+	Permissions [][]string `json:"permissions" yaml:"permissions"`
+	// Parents are the roles the role inherits.
+	Parents []string `json:"parents,omitempty" yaml:"parents,omitempty"`
+}
+
+// Rules returns the rules of the template with its placeholders substituted by vars.
+// It fails if a placeholder has no value in vars.
+func (t RoleTemplate) Rules(vars map[string]string) ([]Rule, error) {
+	var missing error
+	expand := func(s string) string {
+		return placeholderPattern.ReplaceAllStringFunc(s, func(placeholder string) string {
+			name := placeholder[1 : len(placeholder)-1]
+			value, ok := vars[name]
+			if !ok && missing == nil {
+				missing = fmt.Errorf("role template %q: no value for placeholder %s", t.Name, placeholder)
+			}
+			return value
+		})
+	}
+
+	ptype := t.PType
+	if ptype == "" {
+		ptype = "p"
+	}
+	role := expand(t.Name)
+	rules := make([]Rule, 0, len(t.Permissions)+len(t.Parents))
+	for _, permission := range t.Permissions {
+		values := make([]string, 0, len(permission)+1)
+		values = append(values, role)
+		for _, value := range permission {
+			values = append(values, expand(value))
+		}
+		rules = append(rules, NewRule(ptype, values...))
+	}
+	for _, parent := range t.Parents {
+		rules = append(rules, NewRule("g", role, expand(parent)))
+	}
+	if missing != nil {
+		return nil, missing
+	}
+	if role == "" {
+		return nil, fmt.Errorf("role template %q: empty role name", t.Name)
+	}
+	return rules, nil
+}
+
+// InstantiateRoleTemplate adds the rules of the template with its placeholders substituted by vars,
+// and returns the rules added, which excludes those that already existed.
+// The rules are added with Tx, so a failure reverts the rules added so far.
+func (e *Enforcer) InstantiateRoleTemplate(ctx context.Context, t RoleTemplate, vars map[string]string) ([]Rule, error) {
+	rules, err := t.Rules(vars)
+	if err != nil {
+		return nil, err
+	}
+	return e.addRulesTx(ctx, rules)
+}
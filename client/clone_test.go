@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCloneRole(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("p", "data2_admin", "data2", "read"),
+		NewRule("p", "data2_admin", "data2", "write"),
+		NewRule("p", "data3_admin", "data2", "read"),
+		NewRule("g", "data2_admin", "reader"),
+		NewRule("g", "alice", "data2_admin"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+
+	added, err := e.CloneRole(ctx, "data2_admin", "data3_admin", CloneRoleOptions{Parents: true})
+	if err != nil {
+		t.Fatalf("CloneRole err: %v", err)
+	}
+	if len(added) != 2 || added[0].String() != "p, data3_admin, data2, write" || added[1].String() != "g, data3_admin, reader" {
+		t.Errorf("CloneRole = %v", added)
+	}
+	if users, err := e.GetUsersForRole(ctx, "data3_admin"); err != nil || len(users) != 0 {
+		t.Errorf("the members were cloned: %v, %v", users, err)
+	}
+
+	for _, roles := range [][2]string{{"", "x"}, {"data2_admin", ""}, {"data2_admin", "data2_admin"}} {
+		if added, err := e.CloneRole(ctx, roles[0], roles[1], CloneRoleOptions{Parents: true, Members: true}); err == nil {
+			t.Errorf("CloneRole(%q, %q) = %v, supposed to fail", roles[0], roles[1], added)
+		}
+	}
+	if rules, err := e.GetFilteredPolicy(ctx, 0, "x"); err != nil || len(rules) != 0 {
+		t.Errorf("CloneRole with an empty source copied %v, %v", rules, err)
+	}
+}
+
+func TestRoleTemplate(t *testing.T) {
+	tmpl := RoleTemplate{
+		Name:        "{tenant}_admin",
+		Permissions: [][]string{{"/tenants/{tenant}/*", "write"}},
+		Parents:     []string{"{tenant}_reader"},
+	}
+	rules, err := tmpl.Rules(map[string]string{"tenant": "acme"})
+	if err != nil {
+		t.Fatalf("Rules err: %v", err)
+	}
+	if len(rules) != 2 || rules[0].String() != "p, acme_admin, /tenants/acme/*, write" || rules[1].String() != "g, acme_admin, acme_reader" {
+		t.Errorf("Rules = %v", rules)
+	}
+	if _, err := tmpl.Rules(map[string]string{"region": "eu"}); err == nil {
+		t.Errorf("Rules accepted a missing placeholder")
+	}
+}
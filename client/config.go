@@ -0,0 +1,130 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// connectionConfig is the layout of casbin-server's connection config file, e.g. config/connection_config.json.
+type connectionConfig struct {
+	// Driver is the adapter driver, e.g. "file" or "mysql".
+	Driver string `json:"driver"`
+	// Connection is the connection string of the adapter; for the "file" driver, the path of the policy
+	// on the server.
+	Connection string `json:"connection"`
+	// Enforcer is the path of the model file.
+	Enforcer string `json:"enforcer"`
+}
+
+// ConfigFromFile reads a connection config file in casbin-server's JSON layout:
+//
+//	{
+//	  "driver": "mysql",
+//	  "connection": "root:${DB_PASSWORD}@tcp(127.0.0.1:3306)/",
+//	  "enforcer": "rbac_model.conf"
+//	}
+//
+// and returns the Config it describes. The model file named by "enforcer" is read into Config.ModelText,
+// a relative path being relative to the directory of the config file. With no "enforcer", the server picks
+// its default model.
+//
+// Environment variables are expanded in "connection", as $VAR or ${VAR}, so that secrets stay out of the file;
+// "$$" stands for "$". An undefined variable is an error rather than an empty string. With the "file" driver,
+// "connection" is the path of the policy file as seen by the server, and is not read by the client.
+func ConfigFromFile(name string) (Config, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return Config{}, err
+	}
+	return parseConfig(name, data, func(model string) ([]byte, error) {
+		if !filepath.IsAbs(model) {
+			model = filepath.Join(filepath.Dir(name), model)
+		}
+		return os.ReadFile(model)
+	})
+}
+
+// ConfigFromFS is ConfigFromFile on a file system such as an embed.FS:
+//
+//	//go:embed config
+//	var configFS embed.FS
+//
+//	config, err := client.ConfigFromFS(configFS, "config/connection_config.json")
+//
+// As the paths of fsys are relative to its root, an absolute "enforcer" path is read from the root of fsys.
+func ConfigFromFS(fsys fs.FS, name string) (Config, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return Config{}, err
+	}
+	return parseConfig(name, data, func(model string) ([]byte, error) {
+		if strings.HasPrefix(model, "/") {
+			model = strings.TrimPrefix(model, "/")
+		} else {
+			model = path.Join(path.Dir(name), model)
+		}
+		return fs.ReadFile(fsys, model)
+	})
+}
+
+// parseConfig builds the Config described by the connection config data read from name,
+// reading the model file with readModel.
+func parseConfig(name string, data []byte, readModel func(model string) ([]byte, error)) (Config, error) {
+	var cc connectionConfig
+	if err := json.Unmarshal(data, &cc); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", name, err)
+	}
+	connection, err := expandEnv(cc.Connection)
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: connection: %w", name, err)
+	}
+
+	config := Config{DriverName: cc.Driver, ConnectString: connection}
+	if cc.Enforcer != "" {
+		model, err := readModel(cc.Enforcer)
+		if err != nil {
+			return Config{}, fmt.Errorf("%s: enforcer: %w", name, err)
+		}
+		config.ModelText = string(model)
+	}
+	return config, nil
+}
+
+// expandEnv replaces $VAR and ${VAR} in s with the values of the environment variables, and "$$" with "$".
+// It fails if a variable is not defined.
+func expandEnv(s string) (string, error) {
+	missing := make(map[string]bool)
+	expanded := os.Expand(s, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			missing[name] = true
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("undefined environment variables %s", strings.Join(sortedKeys(missing), ", "))
+	}
+	return expanded, nil
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestConfigFromFile(t *testing.T) {
+	example, err := os.ReadFile("../examples/rbac_model.conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "model.conf"), example, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Join(dir, "connection_config.json")
+	data := `{"driver": "mysql", "connection": "root:${CASBIN_TEST_PASSWORD}@tcp(127.0.0.1:3306)/$$x", "enforcer": "model.conf"}`
+	if err := os.WriteFile(name, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ConfigFromFile(name); err == nil || !strings.Contains(err.Error(), "CASBIN_TEST_PASSWORD") {
+		t.Errorf("ConfigFromFile err = %v, supposed to report the undefined variable", err)
+	}
+
+	t.Setenv("CASBIN_TEST_PASSWORD", "secret")
+	config, err := ConfigFromFile(name)
+	if err != nil {
+		t.Fatalf("ConfigFromFile err: %v", err)
+	}
+	want := Config{DriverName: "mysql", ConnectString: "root:secret@tcp(127.0.0.1:3306)/$x", ModelText: string(example)}
+	if config != want {
+		t.Errorf("ConfigFromFile = %+v, supposed to be %+v", config, want)
+	}
+}
+
+func TestConfigFromFS(t *testing.T) {
+	data, err := os.ReadFile("../config/connection_config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys := fstest.MapFS{
+		"config/connection_config.json": {Data: data},
+		"data/examples/rbac_model.conf": {Data: []byte(rbacModelText)},
+	}
+	config, err := ConfigFromFS(fsys, "config/connection_config.json")
+	if err != nil {
+		t.Fatalf("ConfigFromFS err: %v", err)
+	}
+	want := Config{DriverName: "file", ConnectString: "/data/examples/rbac_policy.csv", ModelText: rbacModelText}
+	if config != want {
+		t.Errorf("ConfigFromFS = %+v, supposed to be %+v", config, want)
+	}
+}
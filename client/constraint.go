@@ -0,0 +1,246 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrConstraint is the error wrapped by ConstraintError.
+var ErrConstraint = errors.New("role constraint violated")
+
+// Constraint is a rule on the role assignments of the "g" ptype, registered with Enforcer.AddConstraint.
+// Constraints follow role inheritance, in every domain: a user holds the roles it inherits.
+// The implementations are SoDConstraint, MaxMembersConstraint and PrerequisiteConstraint.
+type Constraint interface {
+	// ConstraintName identifies the constraint in violations.
+	ConstraintName() string
+	violations(ri *roleIndex) []ConstraintViolation
+}
+
+// ConstraintViolation is a user or role breaking a constraint.
+type ConstraintViolation struct {
+	Constraint string `json:"constraint"`
+	Subject    string `json:"subject"`
+	Message    string `json:"message"`
+}
+
+// key identifies the violation, including its details, so that a worsening violation counts as a new one.
+func (v ConstraintViolation) key() string {
+	return v.Constraint + "\x00" + v.Subject + "\x00" + v.Message
+}
+
+// ConstraintError is returned when a role assignment would break a registered constraint.
+type ConstraintError struct {
+	Rule       []string
+	Violations []ConstraintViolation
+}
+
+func (e *ConstraintError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+	return fmt.Sprintf("%v by %q: %s", ErrConstraint, strings.Join(e.Rule, ", "), strings.Join(messages, "; "))
+}
+
+func (e *ConstraintError) Unwrap() error {
+	return ErrConstraint
+}
+
+// SoDConstraint is a static separation of duties: nobody may hold more than Max of Roles, 1 by default.
+type SoDConstraint struct {
+	Name  string
+	Roles []string
+	Max   int
+}
+
+// ConstraintName returns the name of the constraint.
+func (c SoDConstraint) ConstraintName() string { return c.Name }
+
+func (c SoDConstraint) violations(ri *roleIndex) []ConstraintViolation {
+	limit := c.Max
+	if limit <= 0 {
+		limit = 1
+	}
+	var result []ConstraintViolation
+	for _, name := range ri.names() {
+		held := intersect(ri.roles(name, "", true), c.Roles)
+		if len(held) > limit {
+			result = append(result, ConstraintViolation{
+				Constraint: c.Name,
+				Subject:    name,
+				Message:    fmt.Sprintf("%s: %q holds %s, at most %d allowed", c.Name, name, strings.Join(held, ", "), limit),
+			})
+		}
+	}
+	return result
+}
+
+// MaxMembersConstraint limits the number of users holding Role, directly or through other roles.
+// Roles holding Role are not counted.
+type MaxMembersConstraint struct {
+	Name string
+	Role string
+	Max  int
+}
+
+// ConstraintName returns the name of the constraint.
+func (c MaxMembersConstraint) ConstraintName() string { return c.Name }
+
+func (c MaxMembersConstraint) violations(ri *roleIndex) []ConstraintViolation {
+	var members []string
+	for _, user := range ri.users(c.Role, "", true) {
+		if !ri.isRole(user) {
+			members = append(members, user)
+		}
+	}
+	if len(members) <= c.Max {
+		return nil
+	}
+	return []ConstraintViolation{{
+		Constraint: c.Name,
+		Subject:    c.Role,
+		Message:    fmt.Sprintf("%s: role %q has %d members, at most %d allowed", c.Name, c.Role, len(members), c.Max),
+	}}
+}
+
+// PrerequisiteConstraint requires the users assigned Role to hold all of Requires.
+type PrerequisiteConstraint struct {
+	Name     string
+	Role     string
+	Requires []string
+}
+
+// ConstraintName returns the name of the constraint.
+func (c PrerequisiteConstraint) ConstraintName() string { return c.Name }
+
+func (c PrerequisiteConstraint) violations(ri *roleIndex) []ConstraintViolation {
+	var result []ConstraintViolation
+	for _, user := range ri.users(c.Role, "", false) {
+		held := ri.roles(user, "", true)
+		var missing []string
+		for _, required := range c.Requires {
+			if len(intersect(held, []string{required})) == 0 {
+				missing = append(missing, required)
+			}
+		}
+		if len(missing) > 0 {
+			result = append(result, ConstraintViolation{
+				Constraint: c.Name,
+				Subject:    user,
+				Message:    fmt.Sprintf("%s: %q holds %q without %s", c.Name, user, c.Role, strings.Join(missing, ", ")),
+			})
+		}
+	}
+	return result
+}
+
+// intersect returns the values of set found in sorted, in the order of set.
+func intersect(sorted, set []string) []string {
+	var result []string
+	for _, value := range set {
+		if i := sort.SearchStrings(sorted, value); i < len(sorted) && sorted[i] == value {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// AddConstraint registers a constraint checked before AddRoleForUser, AddGroupingPolicy
+// and AddNamedGroupingPolicy with "g" send anything to the server.
+// A constraint with the same name is replaced.
+func (e *Enforcer) AddConstraint(c Constraint) {
+	e.constraintsMu.Lock()
+	defer e.constraintsMu.Unlock()
+	for i, registered := range e.constraints {
+		if registered.ConstraintName() == c.ConstraintName() {
+			e.constraints[i] = c
+			return
+		}
+	}
+	e.constraints = append(e.constraints, c)
+}
+
+// RemoveConstraint unregisters the constraint with the given name.
+func (e *Enforcer) RemoveConstraint(name string) {
+	e.constraintsMu.Lock()
+	defer e.constraintsMu.Unlock()
+	for i, registered := range e.constraints {
+		if registered.ConstraintName() == name {
+			e.constraints = append(e.constraints[:i:i], e.constraints[i+1:]...)
+			return
+		}
+	}
+}
+
+// Constraints returns the registered constraints.
+func (e *Enforcer) Constraints() []Constraint {
+	e.constraintsMu.RLock()
+	defer e.constraintsMu.RUnlock()
+	return append([]Constraint(nil), e.constraints...)
+}
+
+// ValidateConstraints checks the current role inheritance rules against the registered constraints,
+// and returns the violations, e.g. those that existed before the constraints were registered.
+func (e *Enforcer) ValidateConstraints(ctx context.Context) ([]ConstraintViolation, error) {
+	rules, err := e.GetGroupingPolicyRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	violations := []ConstraintViolation{}
+	ri := newRoleIndex(rules)
+	for _, c := range e.Constraints() {
+		violations = append(violations, c.violations(ri)...)
+	}
+	return violations, nil
+}
+
+// checkConstraints returns a *ConstraintError if adding the "g" rule would cause new violations
+// of the registered constraints. Violations that exist without the rule are not reported,
+// so that they do not block unrelated changes.
+func (e *Enforcer) checkConstraints(ctx context.Context, rule []string) error {
+	constraints := e.Constraints()
+	if len(constraints) == 0 {
+		return nil
+	}
+	rules, err := e.GetGroupingPolicyRules(ctx)
+	if err != nil {
+		return err
+	}
+	before := newRoleIndex(rules)
+	after := newRoleIndex(append(rules, NewRule("g", rule...)))
+
+	var violations []ConstraintViolation
+	for _, c := range constraints {
+		existing := make(map[string]bool)
+		for _, v := range c.violations(before) {
+			existing[v.key()] = true
+		}
+		for _, v := range c.violations(after) {
+			if !existing[v.key()] {
+				violations = append(violations, v)
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return &ConstraintError{Rule: rule, Violations: violations}
+	}
+	return nil
+}
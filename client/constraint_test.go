@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConstraints(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("g", "bob", "payments_approver"),
+		NewRule("g", "bob", "payments_submitter"),
+		NewRule("g", "senior_staff", "payments_approver"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+	e.AddConstraint(SoDConstraint{Name: "payments-sod", Roles: []string{"payments_approver", "payments_submitter"}})
+	e.AddConstraint(MaxMembersConstraint{Name: "admins", Role: "admin", Max: 1})
+	e.AddConstraint(PrerequisiteConstraint{Name: "admin-training", Role: "admin", Requires: []string{"trained"}})
+
+	violations, err := e.ValidateConstraints(ctx)
+	if err != nil || len(violations) != 1 || violations[0].Subject != "bob" {
+		t.Errorf("ValidateConstraints = %v, %v", violations, err)
+	}
+
+	if _, err := e.AddRoleForUser(ctx, "alice", "payments_approver"); err != nil {
+		t.Fatalf("AddRoleForUser err: %v", err)
+	}
+	if _, err := e.AddGroupingPolicy(ctx, "carol", "senior_staff"); err != nil {
+		t.Fatalf("AddGroupingPolicy err: %v", err)
+	}
+	// carol holds payments_approver through senior_staff.
+	_, err = e.AddGroupingPolicy(ctx, "carol", "payments_submitter")
+	var constraintErr *ConstraintError
+	if !errors.As(err, &constraintErr) || !errors.Is(err, ErrConstraint) || constraintErr.Violations[0].Constraint != "payments-sod" {
+		t.Errorf("AddGroupingPolicy err = %v, supposed to violate payments-sod", err)
+	}
+
+	if _, err := e.AddRoleForUser(ctx, "dave", "admin"); !errors.Is(err, ErrConstraint) {
+		t.Errorf("AddRoleForUser err = %v, supposed to violate admin-training", err)
+	}
+	e.RemoveConstraint("admin-training")
+	if _, err := e.AddRoleForUser(ctx, "dave", "admin"); err != nil {
+		t.Fatalf("AddRoleForUser err: %v", err)
+	}
+	if _, err := e.AddRoleForUser(ctx, "erin", "admin"); !errors.Is(err, ErrConstraint) {
+		t.Errorf("AddRoleForUser err = %v, supposed to violate admins", err)
+	}
+	if ok, err := e.HasRoleForUser(ctx, "erin", "admin"); ok || err != nil {
+		t.Errorf("a rule violating a constraint was sent: %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePolicyCSV(t *testing.T) {
+	f, err := os.Open("../examples/rbac_policy.csv")
+	if err != nil {
+		t.Fatalf("Open err: %v", err)
+	}
+	defer f.Close()
+
+	rules, err := ParsePolicyCSV(f)
+	if err != nil {
+		t.Fatalf("ParsePolicyCSV err: %v", err)
+	}
+	if len(rules) != 9 {
+		t.Fatalf("ParsePolicyCSV read %d rules, supposed to be 9", len(rules))
+	}
+	if rules[4].String() != "g, alice, data2_admin" {
+		t.Errorf("rules[4] = %q", rules[4].String())
+	}
+}
+
+func TestPolicyCSVRoundTrip(t *testing.T) {
+	text := `# comment
+p, alice, "data1, data2", read
+
+p2,bob,  "say ""hi""", write
+g, alice, admin
+g2, "  spaced ", group
+`
+	rules, err := ParsePolicyCSV(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("ParsePolicyCSV err: %v", err)
+	}
+	want := []Rule{
+		NewRule("p", "alice", "data1, data2", "read"),
+		NewRule("p2", "bob", `say "hi"`, "write"),
+		NewRule("g", "alice", "admin"),
+		NewRule("g2", "  spaced ", "group"),
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("ParsePolicyCSV = %v, supposed to be %v", rules, want)
+	}
+	for i := range want {
+		if !rules[i].Equal(want[i]) {
+			t.Errorf("rules[%d] = %q, supposed to be %q", i, rules[i].String(), want[i].String())
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WritePolicyCSV(&buf, rules); err != nil {
+		t.Fatalf("WritePolicyCSV err: %v", err)
+	}
+	again, err := ParsePolicyCSV(&buf)
+	if err != nil {
+		t.Fatalf("ParsePolicyCSV err: %v", err)
+	}
+	for i := range want {
+		if !again[i].Equal(want[i]) {
+			t.Errorf("round trip rules[%d] = %q, supposed to be %q", i, again[i].String(), want[i].String())
+		}
+	}
+
+	if _, err := ParsePolicyCSV(strings.NewReader(`p, "alice, data1`)); err == nil {
+		t.Errorf("ParsePolicyCSV accepted an unterminated quote")
+	}
+}
+
+func TestImportExportCSV(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	f, err := os.Open("../examples/rbac_policy.csv")
+	if err != nil {
+		t.Fatalf("Open err: %v", err)
+	}
+	defer f.Close()
+	if _, err := e.ImportCSV(ctx, f); err != nil {
+		t.Fatalf("ImportCSV err: %v", err)
+	}
+
+	ok, err := e.Enforce(ctx, "alice", "data2", "write")
+	if err != nil || !ok {
+		t.Errorf("Enforce: %v, %v, supposed to be true", ok, err)
+	}
+
+	var buf bytes.Buffer
+	if err := e.ExportCSV(ctx, &buf); err != nil {
+		t.Fatalf("ExportCSV err: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 9 || lines[0] != "p, alice, data1, read" || lines[6] != "g, alice, data2_admin" {
+		t.Errorf("ExportCSV wrote:\n%s", buf.String())
+	}
+}
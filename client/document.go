@@ -0,0 +1,208 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/casbin/casbin/v2/model"
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyDocumentVersion is the version of the policy document format.
+const PolicyDocumentVersion = "casbin.policy/v1"
+
+// PolicyDocumentSchema is the JSON Schema of a policy document. YAML documents follow the same schema.
+const PolicyDocumentSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://casbin.org/schemas/policy-document-v1.json",
+  "title": "Casbin policy document",
+  "type": "object",
+  "required": ["version", "policies"],
+  "additionalProperties": false,
+  "properties": {
+    "version": {"const": "casbin.policy/v1"},
+    "model": {"type": "string", "description": "Model text, as in Config.ModelText."},
+    "policies": {
+      "type": "object",
+      "description": "Rules keyed by ptype, e.g. \"p\", \"p2\", \"g\", \"g2\".",
+      "propertyNames": {"pattern": "^[pg][0-9]*$"},
+      "additionalProperties": {
+        "type": "array",
+        "items": {"type": "array", "minItems": 1, "items": {"type": "string"}}
+      }
+    }
+  }
+}`
+
+// PolicyDocument is the complete policy of an enforcer, in a form that can be encoded as JSON or YAML.
+type PolicyDocument struct {
+	Version string `json:"version" yaml:"version"`
+	// Model is the model text, empty if the server picked its default model.
+	Model string `json:"model,omitempty" yaml:"model,omitempty"`
+	// Policies holds the rule values keyed by ptype.
+	Policies map[string][][]string `json:"policies" yaml:"policies"`
+}
+
+// NewPolicyDocument creates a policy document holding rules and the model text.
+func NewPolicyDocument(modelText string, rules []Rule) *PolicyDocument {
+	doc := &PolicyDocument{
+		Version:  PolicyDocumentVersion,
+		Model:    modelText,
+		Policies: make(map[string][][]string),
+	}
+	for _, rule := range rules {
+		doc.Policies[rule.PType] = append(doc.Policies[rule.PType], rule.Values)
+	}
+	return doc
+}
+
+// Validate checks that the document follows PolicyDocumentSchema and that its model, if any, can be parsed.
+func (d *PolicyDocument) Validate() error {
+	if d.Version != PolicyDocumentVersion {
+		return fmt.Errorf("unsupported policy document version %q, expected %q", d.Version, PolicyDocumentVersion)
+	}
+	if d.Policies == nil {
+		return errors.New("policy document has no policies")
+	}
+	for ptype, rules := range d.Policies {
+		if !isPType(ptype) {
+			return fmt.Errorf("policy document has an invalid ptype %q", ptype)
+		}
+		for i, values := range rules {
+			if len(values) == 0 {
+				return fmt.Errorf("policy document has an empty rule at %s[%d]", ptype, i)
+			}
+		}
+	}
+	if d.Model != "" {
+		if _, err := model.NewModelFromString(d.Model); err != nil {
+			return fmt.Errorf("policy document has an invalid model: %w", err)
+		}
+	}
+	return nil
+}
+
+// Rules returns the rules of the document, ordered by ptype with policy rules first.
+func (d *PolicyDocument) Rules() []Rule {
+	ptypes := make([]string, 0, len(d.Policies))
+	for ptype := range d.Policies {
+		ptypes = append(ptypes, ptype)
+	}
+	sort.Slice(ptypes, func(i, j int) bool {
+		if isGroupingPType(ptypes[i]) != isGroupingPType(ptypes[j]) {
+			return !isGroupingPType(ptypes[i])
+		}
+		return ptypes[i] < ptypes[j]
+	})
+
+	var rules []Rule
+	for _, ptype := range ptypes {
+		for _, values := range d.Policies[ptype] {
+			rules = append(rules, NewRule(ptype, values...))
+		}
+	}
+	return rules
+}
+
+// WriteJSON writes the document to w as indented JSON.
+func (d *PolicyDocument) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(d)
+}
+
+// WriteYAML writes the document to w as YAML.
+func (d *PolicyDocument) WriteYAML(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(d); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// ReadPolicyDocumentJSON reads and validates a JSON policy document.
+func ReadPolicyDocumentJSON(r io.Reader) (*PolicyDocument, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	doc := &PolicyDocument{}
+	if err := decoder.Decode(doc); err != nil {
+		return nil, err
+	}
+	return doc, doc.Validate()
+}
+
+// ReadPolicyDocumentYAML reads and validates a YAML policy document.
+func ReadPolicyDocumentYAML(r io.Reader) (*PolicyDocument, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	doc := &PolicyDocument{}
+	if err := decoder.Decode(doc); err != nil {
+		return nil, err
+	}
+	return doc, doc.Validate()
+}
+
+// ExportDocument gets the complete policy, every ptype of the model, together with the model text.
+func (e *Enforcer) ExportDocument(ctx context.Context) (*PolicyDocument, error) {
+	rules, err := e.getRules(ctx, e.ptypes())
+	if err != nil {
+		return nil, err
+	}
+	return NewPolicyDocument(e.modelText, rules), nil
+}
+
+// ImportDocument makes the current policy converge to the rules of doc with Apply.
+// Set opts.Prune to replace the policy rather than merge into it.
+// It fails if doc carries a model that differs from the enforcer's, and with ErrUnknownModel if doc carries
+// a model but the enforcer was created without Config.ModelText, as the models cannot be compared;
+// clear doc.Model to import the rules anyway.
+func (e *Enforcer) ImportDocument(ctx context.Context, doc *PolicyDocument, opts ApplyOptions) (*ApplyReport, error) {
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	if doc.Model != "" {
+		if e.model == nil {
+			return nil, fmt.Errorf("policy document has a model: %w", ErrUnknownModel)
+		}
+		m, err := model.NewModelFromString(doc.Model)
+		if err != nil {
+			return nil, fmt.Errorf("policy document model: %w", err)
+		}
+		if m.ToText() != e.model.ToText() {
+			return nil, errors.New("policy document model differs from the enforcer model")
+		}
+	}
+	return e.Apply(ctx, doc.Rules(), opts)
+}
+
+// isPType reports whether ptype is a valid policy or role inheritance ptype, e.g. "p" or "g2".
+func isPType(ptype string) bool {
+	if len(ptype) == 0 || (ptype[0] != 'p' && ptype[0] != 'g') {
+		return false
+	}
+	for _, c := range ptype[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPolicyDocumentRoundTrip(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	rules := []Rule{
+		NewRule("p", "alice", "data1", "read"),
+		NewRule("p", "data2_admin", "data2", "write"),
+		NewRule("g", "alice", "data2_admin"),
+	}
+	if _, err := e.AddRules(ctx, rules); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+	doc, err := e.ExportDocument(ctx)
+	if err != nil {
+		t.Fatalf("ExportDocument err: %v", err)
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		var buf bytes.Buffer
+		var read *PolicyDocument
+		if format == "json" {
+			err = doc.WriteJSON(&buf)
+		} else {
+			err = doc.WriteYAML(&buf)
+		}
+		if err != nil {
+			t.Fatalf("write %s err: %v", format, err)
+		}
+		if format == "json" {
+			read, err = ReadPolicyDocumentJSON(&buf)
+		} else {
+			read, err = ReadPolicyDocumentYAML(&buf)
+		}
+		if err != nil {
+			t.Fatalf("read %s err: %v", format, err)
+		}
+
+		other := newTestEnforcer(t, rbacModelText)
+		report, err := other.ImportDocument(ctx, read, ApplyOptions{Prune: true})
+		if err != nil {
+			t.Fatalf("ImportDocument %s err: %v", format, err)
+		}
+		if len(report.Added) != len(rules) {
+			t.Errorf("ImportDocument %s added %v", format, report.Added)
+		}
+		ok, err := other.Enforce(ctx, "alice", "data2", "write")
+		if err != nil || !ok {
+			t.Errorf("Enforce after %s import: %v, %v, supposed to be true", format, ok, err)
+		}
+	}
+}
+
+func TestImportDocumentModel(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	doc := NewPolicyDocument(rbacWithDomainsModelText, []Rule{NewRule("p", "alice", "domain1", "data1", "read")})
+	if _, err := e.ImportDocument(ctx, doc, ApplyOptions{}); err == nil {
+		t.Errorf("ImportDocument accepted a document of another model")
+	}
+	// The same enforcer, as seen by a client created without Config.ModelText.
+	unknown := &Enforcer{client: e.client, handler: e.handler}
+	if _, err := unknown.ImportDocument(ctx, doc, ApplyOptions{}); !errors.Is(err, ErrUnknownModel) {
+		t.Errorf("ImportDocument err = %v, supposed to be ErrUnknownModel", err)
+	}
+	if policies, err := e.GetPolicy(ctx); err != nil || len(policies) != 0 {
+		t.Errorf("rules were imported: %v, %v", policies, err)
+	}
+}
+
+func TestReadPolicyDocumentInvalid(t *testing.T) {
+	docs := []string{
+		`{"version": "casbin.policy/v0", "policies": {}}`,
+		`{"version": "casbin.policy/v1"}`,
+		`{"version": "casbin.policy/v1", "policies": {"x": [["a"]]}}`,
+		`{"version": "casbin.policy/v1", "policies": {"p": [[]]}}`,
+		`{"version": "casbin.policy/v1", "policies": {}, "extra": 1}`,
+	}
+	for _, doc := range docs {
+		if _, err := ReadPolicyDocumentJSON(strings.NewReader(doc)); err == nil {
+			t.Errorf("ReadPolicyDocumentJSON(%s) err: nil", doc)
+		}
+	}
+}
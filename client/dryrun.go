@@ -0,0 +1,264 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+)
+
+// ErrUnknownModel is returned by the features that evaluate the policy locally
+// when the enforcer was created without Config.ModelText.
+var ErrUnknownModel = errors.New("the model is unknown, set Config.ModelText")
+
+// DryRunOptions controls the evaluation of Enforcer.DryRun.
+type DryRunOptions struct {
+	// Probes are the requests to evaluate, e.g. {"alice", "data1", "read"}.
+	// If empty, every combination of subject, object and action of the policy is evaluated,
+	// which needs a model whose request has exactly these three fields.
+	Probes [][]string
+}
+
+// ImpactReport describes how a set of proposed mutations would change the decisions of the enforcer.
+type ImpactReport struct {
+	// Ops are the recorded operations, with filtered removals listed as the removals of the rules they match.
+	Ops []TxOp `json:"ops"`
+	// Gained lists the requests that are denied now and would be allowed.
+	Gained [][]string `json:"gained"`
+	// Lost lists the requests that are allowed now and would be denied.
+	Lost [][]string `json:"lost"`
+	// Evaluated is the number of requests evaluated.
+	Evaluated int `json:"evaluated"`
+}
+
+// DryRun records mutations like Tx, applies them to a local copy of the policy instead of the server,
+// and reports the requests whose decision would change. The copy is evaluated with Config.ModelText,
+// so DryRun returns ErrUnknownModel if the enforcer was created without it.
+//
+//	report, err := e.DryRun(ctx, func(tx *client.PolicyTx) error {
+//		tx.DeleteRole("data2_admin")
+//		return nil
+//	}, client.DryRunOptions{})
+func (e *Enforcer) DryRun(ctx context.Context, fn func(tx *PolicyTx) error, opts DryRunOptions) (*ImpactReport, error) {
+	tx := &PolicyTx{}
+	if err := fn(tx); err != nil {
+		return nil, err
+	}
+	if tx.err != nil {
+		return nil, tx.err
+	}
+
+	rules, err := e.getRules(ctx, e.ptypes())
+	if err != nil {
+		return nil, err
+	}
+	before, err := e.localEnforcer(rules)
+	if err != nil {
+		return nil, err
+	}
+	after, err := e.localEnforcer(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImpactReport{Ops: []TxOp{}, Gained: [][]string{}, Lost: [][]string{}}
+	for _, recorded := range tx.ops {
+		ops := []TxOp{recorded}
+		if recorded.Action == TxRemoveFiltered {
+			ops, err = expandFilteredLocal(after, recorded)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, op := range ops {
+			op.Affected, err = applyLocalOp(after, op)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op.String(), err)
+			}
+			report.Ops = append(report.Ops, op)
+		}
+	}
+
+	probes := opts.Probes
+	if len(probes) == 0 {
+		probes, err = crossProduct(before, after)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, probe := range probes {
+		rvals := make([]interface{}, len(probe))
+		for i, value := range probe {
+			rvals[i] = value
+		}
+		was, err := before.Enforce(rvals...)
+		if err != nil {
+			return nil, fmt.Errorf("evaluate %q: %w", strings.Join(probe, ", "), err)
+		}
+		is, err := after.Enforce(rvals...)
+		if err != nil {
+			return nil, fmt.Errorf("evaluate %q: %w", strings.Join(probe, ", "), err)
+		}
+		switch {
+		case !was && is:
+			report.Gained = append(report.Gained, probe)
+		case was && !is:
+			report.Lost = append(report.Lost, probe)
+		}
+		report.Evaluated++
+	}
+	return report, nil
+}
+
+// localEnforcer returns an in-memory casbin enforcer with the model of e and the given rules.
+func (e *Enforcer) localEnforcer(rules []Rule) (*casbin.Enforcer, error) {
+	if e.modelText == "" {
+		return nil, ErrUnknownModel
+	}
+	m, err := model.NewModelFromString(e.modelText)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		sec := "p"
+		if rule.IsGrouping() {
+			sec = "g"
+		}
+		if err := m.AddPolicy(sec, rule.PType, rule.Values); err != nil {
+			return nil, fmt.Errorf("load rule %q: %w", rule.String(), err)
+		}
+	}
+	local, err := casbin.NewEnforcer(m)
+	if err != nil {
+		return nil, err
+	}
+	if err := local.BuildRoleLinks(); err != nil {
+		return nil, err
+	}
+	return local, nil
+}
+
+// expandFilteredLocal is expandFiltered on a local enforcer.
+func expandFilteredLocal(local *casbin.Enforcer, op TxOp) ([]TxOp, error) {
+	var rules [][]string
+	var err error
+	if isGroupingPType(op.PType) {
+		rules, err = local.GetFilteredNamedGroupingPolicy(op.PType, op.FieldIndex, op.Rule...)
+	} else {
+		rules, err = local.GetFilteredNamedPolicy(op.PType, op.FieldIndex, op.Rule...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return removeOps(op.PType, rules), nil
+}
+
+// applyLocalOp is applyTxOp on a local enforcer.
+func applyLocalOp(local *casbin.Enforcer, op TxOp) (bool, error) {
+	if op.Action == TxAdd {
+		var exists bool
+		var err error
+		if isGroupingPType(op.PType) {
+			exists, err = local.HasNamedGroupingPolicy(op.PType, op.Rule)
+		} else {
+			exists, err = local.HasNamedPolicy(op.PType, op.Rule)
+		}
+		if exists || err != nil {
+			return false, err
+		}
+	}
+
+	switch {
+	case op.Action == TxAdd && isGroupingPType(op.PType):
+		return local.AddNamedGroupingPolicy(op.PType, op.Rule)
+	case op.Action == TxAdd:
+		return local.AddNamedPolicy(op.PType, op.Rule)
+	case op.Action == TxRemove && isGroupingPType(op.PType):
+		return local.RemoveNamedGroupingPolicy(op.PType, op.Rule)
+	case op.Action == TxRemove:
+		return local.RemoveNamedPolicy(op.PType, op.Rule)
+	}
+	return false, fmt.Errorf("unknown transaction action %q", op.Action)
+}
+
+// crossProduct returns every request made of a subject, an object and an action found in the policies
+// before or after the mutations. The users of "g" rules are subjects too, as they hold permissions through roles.
+func crossProduct(before, after *casbin.Enforcer) ([][]string, error) {
+	if err := requireSubObjAct(before); err != nil {
+		return nil, fmt.Errorf("%w, probes are needed", err)
+	}
+
+	subjects := make(map[string]bool)
+	objects := make(map[string]bool)
+	actions := make(map[string]bool)
+	for _, local := range []*casbin.Enforcer{before, after} {
+		for _, get := range []struct {
+			values func() ([]string, error)
+			set    map[string]bool
+		}{
+			{local.GetAllSubjects, subjects},
+			{local.GetAllObjects, objects},
+			{local.GetAllActions, actions},
+		} {
+			values, err := get.values()
+			if err != nil {
+				return nil, err
+			}
+			for _, value := range values {
+				get.set[value] = true
+			}
+		}
+		grouping, err := local.GetGroupingPolicy()
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range grouping {
+			subjects[rule[0]] = true
+		}
+	}
+
+	var probes [][]string
+	for _, sub := range sortedKeys(subjects) {
+		for _, obj := range sortedKeys(objects) {
+			for _, act := range sortedKeys(actions) {
+				probes = append(probes, []string{sub, obj, act})
+			}
+		}
+	}
+	return probes, nil
+}
+
+// requireSubObjAct checks that the requests of the model have three fields, the subject, object and action.
+func requireSubObjAct(local *casbin.Enforcer) error {
+	if tokens := local.GetModel()["r"]["r"].Tokens; len(tokens) != 3 {
+		return fmt.Errorf("the request of the model has %d fields instead of subject, object and action", len(tokens))
+	}
+	return nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDryRun(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("p", "alice", "data1", "read"),
+		NewRule("p", "data2_admin", "data2", "read"),
+		NewRule("g", "bob", "data2_admin"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+
+	report, err := e.DryRun(ctx, func(tx *PolicyTx) error {
+		tx.DeleteRole("data2_admin")
+		tx.AddPermissionForUser("bob", "data1", "read")
+		return nil
+	}, DryRunOptions{})
+	if err != nil {
+		t.Fatalf("DryRun err: %v", err)
+	}
+	if len(report.Ops) != 3 {
+		t.Errorf("Ops = %v, supposed to have 3 operations", report.Ops)
+	}
+	if want := [][]string{{"bob", "data1", "read"}}; !reflect.DeepEqual(report.Gained, want) {
+		t.Errorf("Gained = %v, supposed to be %v", report.Gained, want)
+	}
+	if want := [][]string{{"bob", "data2", "read"}, {"data2_admin", "data2", "read"}}; !reflect.DeepEqual(report.Lost, want) {
+		t.Errorf("Lost = %v, supposed to be %v", report.Lost, want)
+	}
+
+	rules, err := e.GetPolicyRules(ctx)
+	if err != nil || len(rules) != 2 {
+		t.Errorf("the dry run changed the policy: %v, %v", rules, err)
+	}
+
+	if _, err := e.Tx(ctx, func(tx *PolicyTx) error {
+		tx.DeleteRole("data2_admin")
+		return nil
+	}); err != nil {
+		t.Fatalf("Tx err: %v", err)
+	}
+	policies, err := e.GetPolicy(ctx)
+	if err != nil {
+		t.Fatalf("GetPolicy err: %v", err)
+	}
+	testGetPolicy(t, policies, [][]string{{"alice", "data1", "read"}})
+}
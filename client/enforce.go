@@ -16,11 +16,12 @@ package client
 
 import (
 	"context"
-	"fmt"
 	"reflect"
+	"sync"
 
 	pb "github.com/casbin/casbin-server/proto"
 	"github.com/casbin/casbin-server/server"
+	"github.com/casbin/casbin/v2/model"
 )
 
 // Config contains data needed to create an enforcer.
@@ -36,6 +37,12 @@ type Config struct {
 type Enforcer struct {
 	handler int32
 	client  *Client
+	// modelText is Config.ModelText, and model its parsed form, or nil if the server picked its default model.
+	modelText string
+	model     model.Model
+
+	constraintsMu sync.RWMutex
+	constraints   []Constraint
 }
 
 // NewEnforcer creates an enforcer via file or DB.
@@ -44,10 +51,25 @@ type Enforcer struct {
 // MySQL DB:
 // a := mysqladapter.NewDBAdapter("mysql", "mysql_username:mysql_password@tcp(127.0.0.1:3306)/")
 // e := casbin.NewEnforcer("path/to/basic_model.conf", a)
+//
+// A non-empty Config.ModelText is checked by ValidateModel before any request is sent,
+// so that a broken model fails with a *ModelError giving the line of the problem.
 func (c *Client) NewEnforcer(ctx context.Context, config Config) (*Enforcer, error) {
 	var adapterHandler int32 = -1
 	enforcer := &Enforcer{client: c}
 
+	if config.ModelText != "" {
+		if err := ValidateModel(config.ModelText); err != nil {
+			return enforcer, err
+		}
+		m, err := model.NewModelFromString(config.ModelText)
+		if err != nil {
+			return enforcer, err
+		}
+		enforcer.modelText = config.ModelText
+		enforcer.model = m
+	}
+
 	// Maybe it does not need NewAdapter.
 	if config.DriverName != "" && config.ConnectString != "" {
 		adapterReply, err := c.remoteClient.NewAdapter(ctx, &pb.NewAdapterRequest{
@@ -75,18 +97,23 @@ func (c *Client) NewEnforcer(ctx context.Context, config Config) (*Enforcer, err
 }
 
 // Enforce decides whether a "subject" can access a "object" with the operation "action", input parameters are usually: (sub, obj, act).
+// Structs are sent as ABAC attributes, other params are formatted like the values of policy rules;
+// a nil or unsupported param fails with a *ParamError.
 func (e *Enforcer) Enforce(ctx context.Context, params ...interface{}) (bool, error) {
 	var data []string
-	for _, item := range params {
+	for i, item := range params {
 		var value string
 		var err error
-		if reflect.TypeOf(item).Kind() == reflect.Struct {
+		if item != nil && reflect.TypeOf(item).Kind() == reflect.Struct {
 			value, err = server.MakeABAC(item)
 			if err != nil {
 				return false, err
 			}
 		} else {
-			value = fmt.Sprintf("%v", item)
+			value, err = paramToString(item)
+			if err != nil {
+				return false, &ParamError{Index: i, Value: item, Reason: err.Error()}
+			}
 		}
 		data = append(data, value)
 	}
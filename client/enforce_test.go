@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"log"
 	"testing"
 	"time"
@@ -87,6 +88,12 @@ func testEnforce(t *testing.T) {
 		t.Fatalf("Not found error: invalid request size")
 	}
 
+	_, err = e.Enforce(ctx, "alice", nil, "read")
+	var paramErr *ParamError
+	if !errors.As(err, &paramErr) || !errors.Is(err, ErrInvalidParams) || paramErr.Index != 1 {
+		t.Errorf("Enforce with a nil param err: %v, supposed to be a *ParamError", err)
+	}
+
 	res, err := e.Enforce(ctx, "alice", "data1", "read")
 	if err != nil {
 		t.Fatalf("Remove err: %v", err)
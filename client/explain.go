@@ -0,0 +1,99 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import "context"
+
+// PermissionPath explains how a user holds a permission.
+type PermissionPath struct {
+	// Roles are the role inheritance rules linking the user to the subject of Grant, in order.
+	// It is empty if the permission is granted to the user directly.
+	Roles []Rule `json:"roles"`
+	// Grant is the authorization rule that grants the permission.
+	Grant Rule `json:"grant"`
+}
+
+// ExplainRole returns every path of role inheritance rules by which user has role, e.g. for
+// g, alice, data2_admin
+// g, data2_admin, data_admin
+//
+// ExplainRole("alice", "data_admin") will get: [["g, alice, data2_admin", "g, data2_admin, data_admin"]].
+// The rules of every domain are followed, unless a domain is given. A path never goes through the same name twice.
+func (e *Enforcer) ExplainRole(ctx context.Context, user, role string, domain ...string) ([][]Rule, error) {
+	rules, err := e.GetGroupingPolicyRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dom := ""
+	if len(domain) > 0 {
+		dom = domain[0]
+	}
+	return edgePaths(newRoleIndex(rules).paths(user, role, dom)), nil
+}
+
+// ExplainPermission returns every way by which user holds the permission, i.e. every authorization rule
+// whose fields after the subject equal permission, granted to the user or to one of its roles,
+// with the paths of role inheritance rules leading to it.
+// With a model having a "dom" field, only the role inheritance rules of the domain of each rule are followed.
+func (e *Enforcer) ExplainPermission(ctx context.Context, user string, permission ...string) ([]PermissionPath, error) {
+	grouping, err := e.GetGroupingPolicyRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := e.GetPolicyRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ri := newRoleIndex(grouping)
+	paths := []PermissionPath{}
+	for _, rule := range policy {
+		if !grants(rule, permission) {
+			continue
+		}
+		if rule.Values[0] == user {
+			paths = append(paths, PermissionPath{Roles: []Rule{}, Grant: rule})
+			continue
+		}
+		for _, roles := range edgePaths(ri.paths(user, rule.Values[0], rule.Dom())) {
+			paths = append(paths, PermissionPath{Roles: roles, Grant: rule})
+		}
+	}
+	return paths, nil
+}
+
+// grants reports whether the fields of rule after the subject equal permission.
+func grants(rule Rule, permission []string) bool {
+	if len(rule.Values) != len(permission)+1 {
+		return false
+	}
+	for i, value := range permission {
+		if rule.Values[i+1] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func edgePaths(paths [][]roleEdge) [][]Rule {
+	result := make([][]Rule, len(paths))
+	for i, path := range paths {
+		result[i] = make([]Rule, len(path))
+		for j, edge := range path {
+			result[i][j] = edge.rule
+		}
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestExplain(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("p", "data_admin", "data2", "read"),
+		NewRule("p", "alice", "data2", "read"),
+		NewRule("g", "alice", "data2_admin"),
+		NewRule("g", "alice", "auditor"),
+		NewRule("g", "data2_admin", "data_admin"),
+		NewRule("g", "auditor", "data_admin"),
+		NewRule("g", "data_admin", "alice"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+
+	paths, err := e.ExplainRole(ctx, "alice", "data_admin")
+	if err != nil {
+		t.Fatalf("ExplainRole err: %v", err)
+	}
+	if len(paths) != 2 || len(paths[0]) != 2 || paths[0][0].Role() != "data2_admin" || paths[1][0].Role() != "auditor" {
+		t.Errorf("ExplainRole = %v", paths)
+	}
+	if paths, err := e.ExplainRole(ctx, "alice", "bob"); err != nil || len(paths) != 0 {
+		t.Errorf("ExplainRole = %v, %v, supposed to be empty", paths, err)
+	}
+
+	grants, err := e.ExplainPermission(ctx, "alice", "data2", "read")
+	if err != nil {
+		t.Fatalf("ExplainPermission err: %v", err)
+	}
+	if len(grants) != 3 {
+		t.Fatalf("ExplainPermission = %v, supposed to have 3 paths", grants)
+	}
+	for _, grant := range grants {
+		if grant.Grant.Sub() == "alice" && len(grant.Roles) != 0 || grant.Grant.Sub() == "data_admin" && len(grant.Roles) != 2 {
+			t.Errorf("ExplainPermission path %v", grant)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+)
+
+type matchKind int
+
+const (
+	matchExact matchKind = iota
+	matchGlob
+	matchRegex
+)
+
+type fieldCond struct {
+	name    string
+	kind    matchKind
+	pattern string
+	re      *regexp.Regexp
+	index   int
+}
+
+func (c fieldCond) match(value string) bool {
+	switch c.kind {
+	case matchGlob:
+		ok, _ := path.Match(c.pattern, value)
+		return ok
+	case matchRegex:
+		return c.re.MatchString(value)
+	}
+	return value == c.pattern
+}
+
+// PolicyFilter selects rules by the names of their fields, as declared in the model's policy_definition
+// ("sub", "obj", "act", ...). Role inheritance rules have the fields "user", "role" and "domain".
+// All the conditions of a filter must hold for a rule to match.
+//
+//	rules, err := e.GetRulesByFilter(ctx, client.Filter().Field("obj", "data1").Field("act", "read"))
+type PolicyFilter struct {
+	ptype string
+	conds []fieldCond
+	err   error
+}
+
+// Filter creates a filter on the rules of ptype "p" that matches every rule.
+func Filter() *PolicyFilter {
+	return &PolicyFilter{ptype: "p"}
+}
+
+// PType sets the ptype of the filtered rules, e.g. "p2" or "g".
+func (f *PolicyFilter) PType(ptype string) *PolicyFilter {
+	f.ptype = ptype
+	return f
+}
+
+// Field requires the field named name to equal value.
+func (f *PolicyFilter) Field(name, value string) *PolicyFilter {
+	f.conds = append(f.conds, fieldCond{name: name, kind: matchExact, pattern: value})
+	return f
+}
+
+// Glob requires the field named name to match the shell pattern, with the syntax of path.Match.
+func (f *PolicyFilter) Glob(name, pattern string) *PolicyFilter {
+	if _, err := path.Match(pattern, ""); err != nil && f.err == nil {
+		f.err = fmt.Errorf("invalid glob for field %q: %w", name, err)
+	}
+	f.conds = append(f.conds, fieldCond{name: name, kind: matchGlob, pattern: pattern})
+	return f
+}
+
+// Regex requires the field named name to match the regular expression, which is not anchored.
+func (f *PolicyFilter) Regex(name, pattern string) *PolicyFilter {
+	re, err := regexp.Compile(pattern)
+	if err != nil && f.err == nil {
+		f.err = fmt.Errorf("invalid regex for field %q: %w", name, err)
+	}
+	f.conds = append(f.conds, fieldCond{name: name, kind: matchRegex, pattern: pattern, re: re})
+	return f
+}
+
+// Match reports whether rule matches the filter. Field names are resolved with the rule's tokens.
+func (f *PolicyFilter) Match(rule Rule) bool {
+	if f.err != nil || rule.PType != f.ptype {
+		return false
+	}
+	for _, cond := range f.conds {
+		value, ok := rule.Field(cond.name)
+		if !ok || !cond.match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolve returns the conditions of f with the positions of their fields in rules of f.ptype.
+func (e *Enforcer) resolve(f *PolicyFilter) ([]fieldCond, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	conds := make([]fieldCond, len(f.conds))
+	for i, cond := range f.conds {
+		index, ok := e.fieldIndex(f.ptype, cond.name)
+		if !ok {
+			return nil, fmt.Errorf("ptype %q has no field named %q", f.ptype, cond.name)
+		}
+		cond.index = index
+		conds[i] = cond
+	}
+	return conds, nil
+}
+
+// serverFilter turns the exact conditions into the field index and values of a server-side filtered query,
+// using empty values, which match anything, for the fields in between.
+// It reports whether the query selects exactly the rules matching conds.
+func serverFilter(conds []fieldCond) (fieldIndex int32, fieldValues []string, exact bool) {
+	lo, hi := -1, -1
+	exact = true
+	for _, cond := range conds {
+		if cond.kind != matchExact || cond.pattern == "" {
+			exact = false
+			continue
+		}
+		if lo == -1 || cond.index < lo {
+			lo = cond.index
+		}
+		if cond.index > hi {
+			hi = cond.index
+		}
+	}
+	if lo == -1 {
+		return 0, nil, exact && len(conds) == 0
+	}
+
+	fieldValues = make([]string, hi-lo+1)
+	for _, cond := range conds {
+		if cond.kind == matchExact && cond.pattern != "" {
+			if fieldValues[cond.index-lo] != "" && fieldValues[cond.index-lo] != cond.pattern {
+				// Contradicting conditions, left to the client-side check.
+				exact = false
+			}
+			fieldValues[cond.index-lo] = cond.pattern
+		}
+	}
+	return int32(lo), fieldValues, exact
+}
+
+// GetRulesByFilter gets the rules matching the filter.
+// Exact field conditions are sent to the server as a filtered query, and the others are checked locally.
+func (e *Enforcer) GetRulesByFilter(ctx context.Context, f *PolicyFilter) ([]Rule, error) {
+	rules, _, err := e.getRulesByFilter(ctx, f)
+	return rules, err
+}
+
+func (e *Enforcer) getRulesByFilter(ctx context.Context, f *PolicyFilter) ([]Rule, bool, error) {
+	conds, err := e.resolve(f)
+	if err != nil {
+		return nil, false, err
+	}
+
+	fieldIndex, fieldValues, exact := serverFilter(conds)
+	var rules []Rule
+	switch {
+	case len(fieldValues) == 0:
+		rules, err = e.getRules(ctx, []string{f.ptype})
+	case isGroupingPType(f.ptype):
+		rules, err = e.GetFilteredNamedGroupingPolicyRules(ctx, f.ptype, fieldIndex, fieldValues...)
+	default:
+		rules, err = e.GetFilteredNamedPolicyRules(ctx, f.ptype, fieldIndex, fieldValues...)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	matched := rules[:0]
+	for _, rule := range rules {
+		if matchConds(conds, rule.Values) {
+			matched = append(matched, rule)
+		}
+	}
+	return matched, exact && len(fieldValues) > 0, nil
+}
+
+func matchConds(conds []fieldCond, values []string) bool {
+	for _, cond := range conds {
+		if cond.index >= len(values) || !cond.match(values[cond.index]) {
+			return false
+		}
+	}
+	return true
+}
+
+// RemoveRulesByFilter removes the rules matching the filter, and returns them.
+// A filter made only of exact field conditions is removed with a single server-side filtered removal,
+// other filters remove the matching rules one by one.
+func (e *Enforcer) RemoveRulesByFilter(ctx context.Context, f *PolicyFilter) ([]Rule, error) {
+	rules, exact, err := e.getRulesByFilter(ctx, f)
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+
+	if exact {
+		conds, _ := e.resolve(f)
+		fieldIndex, fieldValues, _ := serverFilter(conds)
+		if isGroupingPType(f.ptype) {
+			_, err = e.RemoveFilteredNamedGroupingPolicy(ctx, f.ptype, fieldIndex, fieldValues...)
+		} else {
+			_, err = e.RemoveFilteredNamedPolicy(ctx, f.ptype, fieldIndex, fieldValues...)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return rules, nil
+	}
+
+	removed := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		ok, err := e.RemoveRule(ctx, rule)
+		if err != nil {
+			return removed, err
+		}
+		if ok {
+			removed = append(removed, rule)
+		}
+	}
+	return removed, nil
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRulesByFilter(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("p", "alice", "data1", "read"),
+		NewRule("p", "bob", "data1", "write"),
+		NewRule("p", "carol", "data1", "read"),
+		NewRule("p", "carol", "report/2024", "read"),
+		NewRule("g", "alice", "admin"),
+		NewRule("g", "bob", "auditor"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+
+	tests := []struct {
+		filter *PolicyFilter
+		count  int
+	}{
+		{Filter().Field("obj", "data1").Field("act", "read"), 2},
+		{Filter().Field("sub", "carol").Field("act", "read"), 2},
+		{Filter().Glob("obj", "report/*"), 1},
+		{Filter().Regex("sub", "^(alice|bob)$").Field("obj", "data1"), 2},
+		{Filter().PType("g").Field("role", "admin"), 1},
+		{Filter().Field("obj", "data1").Field("obj", "data2"), 0},
+	}
+	for _, test := range tests {
+		rules, err := e.GetRulesByFilter(ctx, test.filter)
+		if err != nil {
+			t.Fatalf("GetRulesByFilter err: %v", err)
+		}
+		if len(rules) != test.count {
+			t.Errorf("GetRulesByFilter(%+v) = %v, supposed to have %d rules", test.filter.conds, rules, test.count)
+		}
+	}
+
+	if _, err := e.GetRulesByFilter(ctx, Filter().Field("dom", "x")); err == nil {
+		t.Errorf("GetRulesByFilter accepted an unknown field")
+	}
+	if _, err := e.GetRulesByFilter(ctx, Filter().Regex("sub", "(")); err == nil {
+		t.Errorf("GetRulesByFilter accepted an invalid regex")
+	}
+
+	removed, err := e.RemoveRulesByFilter(ctx, Filter().Field("obj", "data1").Field("act", "read"))
+	if err != nil || len(removed) != 2 {
+		t.Fatalf("RemoveRulesByFilter = %v, %v", removed, err)
+	}
+	removed, err = e.RemoveRulesByFilter(ctx, Filter().Glob("obj", "report/*"))
+	if err != nil || len(removed) != 1 {
+		t.Fatalf("RemoveRulesByFilter = %v, %v", removed, err)
+	}
+	policies, err := e.GetPolicy(ctx)
+	if err != nil {
+		t.Fatalf("GetPolicy err: %v", err)
+	}
+	testGetPolicy(t, policies, [][]string{{"bob", "data1", "write"}})
+}
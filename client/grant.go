@@ -0,0 +1,391 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultGrantStorePath is the file keeping the grants of a GrantManager created without a GrantStore.
+// It is relative to the working directory at the time the manager is created.
+const DefaultGrantStorePath = "casbin_grants.json"
+
+// DefaultReapInterval is how often a started GrantManager revokes expired grants, unless set in GrantOptions.
+const DefaultReapInterval = time.Minute
+
+// Grant is a rule added for a limited time.
+type Grant struct {
+	// ID identifies the grant, it is the rule as a JSON array of its ptype and values, e.g. `["g","alice","oncall"]`.
+	ID        string    `json:"id"`
+	Rule      Rule      `json:"rule"`
+	GrantedAt time.Time `json:"grantedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// grantID returns the ID of the grant of rule. Unlike the text form of the rule,
+// it cannot be the same for two rules whose values contain ", ".
+func grantID(rule Rule) string {
+	data, _ := json.Marshal(append([]string{rule.PType}, rule.Values...))
+	return string(data)
+}
+
+// GrantStore persists the grants of a GrantManager, so that they are revoked even after a restart.
+// Its methods may be called concurrently.
+type GrantStore interface {
+	// Put creates or replaces the grant with the same ID.
+	Put(ctx context.Context, grant Grant) error
+	// Delete removes the grant with the given ID, if any.
+	Delete(ctx context.Context, id string) error
+	// List returns all the grants.
+	List(ctx context.Context) ([]Grant, error)
+}
+
+// FileGrantStore is a GrantStore keeping the grants in a JSON file.
+type FileGrantStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileGrantStore creates a store keeping the grants in the JSON file at path, created on the first grant.
+func NewFileGrantStore(path string) *FileGrantStore {
+	return &FileGrantStore{path: path}
+}
+
+// Put creates or replaces the grant with the same ID.
+func (s *FileGrantStore) Put(ctx context.Context, grant Grant) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	grants, err := s.read()
+	if err != nil {
+		return err
+	}
+	grants[grant.ID] = grant
+	return s.write(grants)
+}
+
+// Delete removes the grant with the given ID, if any.
+func (s *FileGrantStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	grants, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := grants[id]; !ok {
+		return nil
+	}
+	delete(grants, id)
+	return s.write(grants)
+}
+
+// List returns all the grants, sorted by ID.
+func (s *FileGrantStore) List(ctx context.Context) ([]Grant, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	grants, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return sortedGrants(grants), nil
+}
+
+func (s *FileGrantStore) read() (map[string]Grant, error) {
+	grants := make(map[string]Grant)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return grants, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list []Grant
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("grant store %s: %w", s.path, err)
+	}
+	for _, grant := range list {
+		grants[grant.ID] = grant
+	}
+	return grants, nil
+}
+
+// write replaces the file through a temporary file, so that a crash never leaves it half written.
+func (s *FileGrantStore) write(grants map[string]Grant) error {
+	data, err := json.MarshalIndent(sortedGrants(grants), "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func sortedGrants(grants map[string]Grant) []Grant {
+	list := make([]Grant, 0, len(grants))
+	for _, grant := range grants {
+		list = append(list, grant)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// GrantEventType is the kind of a GrantEvent.
+type GrantEventType string
+
+const (
+	// GrantEventGranted is emitted when a grant is created or extended.
+	GrantEventGranted GrantEventType = "granted"
+	// GrantEventExpired is emitted when an expired grant has been revoked.
+	GrantEventExpired GrantEventType = "expired"
+	// GrantEventRevoked is emitted when a grant has been revoked before its expiry with GrantManager.Revoke.
+	GrantEventRevoked GrantEventType = "revoked"
+	// GrantEventError is emitted when an expired grant could not be revoked. It is retried on the next reap.
+	GrantEventError GrantEventType = "error"
+)
+
+// GrantEvent reports a change of a grant to GrantOptions.OnEvent.
+type GrantEvent struct {
+	Type  GrantEventType
+	Grant Grant
+	// Err is the error of a GrantEventError event.
+	Err error
+}
+
+// GrantOptions configures a GrantManager.
+type GrantOptions struct {
+	// Store persists the grants, a FileGrantStore at DefaultGrantStorePath by default.
+	Store GrantStore
+	// ReapInterval is how often a started manager revokes the expired grants, DefaultReapInterval by default.
+	ReapInterval time.Duration
+	// OnEvent, if set, is called synchronously on every grant event, from the goroutine of the call
+	// causing the event or from the goroutine started by GrantManager.Start, so it may be called concurrently.
+	OnEvent func(GrantEvent)
+}
+
+// GrantManager adds rules for a limited time and revokes them once expired:
+//
+//	grants, err := client.NewGrantManager(e, client.GrantOptions{})
+//	if err != nil {
+//		...
+//	}
+//	grants.Start(ctx)
+//	defer grants.Close()
+//	_, err = grants.GrantRoleFor(ctx, "alice", "oncall", 8*time.Hour)
+//
+// Grants are kept in a GrantStore, a file by default, so a manager started after a restart revokes the grants
+// that expired in the meantime, and the others when they expire. Set GrantOptions.Store to keep them
+// elsewhere, e.g. client.NewFileGrantStore("/var/lib/app/grants.json").
+type GrantManager struct {
+	enforcer *Enforcer
+	opts     GrantOptions
+	now      func() time.Time
+
+	mu   sync.Mutex
+	stop context.CancelFunc
+	done chan struct{}
+}
+
+// NewGrantManager creates a manager of the grants made on e.
+// Without opts.Store, the grants are kept in DefaultGrantStorePath, resolved against the current working
+// directory now, so that changing directory later does not lose them.
+func NewGrantManager(e *Enforcer, opts GrantOptions) (*GrantManager, error) {
+	if opts.Store == nil {
+		path, err := filepath.Abs(DefaultGrantStorePath)
+		if err != nil {
+			return nil, err
+		}
+		opts.Store = NewFileGrantStore(path)
+	}
+	if opts.ReapInterval <= 0 {
+		opts.ReapInterval = DefaultReapInterval
+	}
+	return &GrantManager{enforcer: e, opts: opts, now: time.Now}, nil
+}
+
+// GrantRoleFor gives role to user for the duration ttl.
+func (m *GrantManager) GrantRoleFor(ctx context.Context, user, role string, ttl time.Duration) (Grant, error) {
+	return m.GrantFor(ctx, NewRule("g", user, role), ttl)
+}
+
+// GrantPermissionFor gives a permission to a user or role for the duration ttl.
+func (m *GrantManager) GrantPermissionFor(ctx context.Context, user string, ttl time.Duration, permission ...string) (Grant, error) {
+	return m.GrantFor(ctx, NewRule("p", append([]string{user}, permission...)...), ttl)
+}
+
+// GrantFor adds rule for the duration ttl.
+// Granting a rule again moves its expiry to ttl from now. Granting a rule that exists but was not granted
+// by the manager fails, as it would revoke a permanent rule.
+func (m *GrantManager) GrantFor(ctx context.Context, rule Rule, ttl time.Duration) (Grant, error) {
+	if ttl <= 0 {
+		return Grant{}, fmt.Errorf("grant %q: ttl must be positive, got %v", rule.String(), ttl)
+	}
+	grants, err := m.opts.Store.List(ctx)
+	if err != nil {
+		return Grant{}, err
+	}
+	id := grantID(rule)
+	granted := false
+	for _, grant := range grants {
+		if grant.ID == id {
+			granted = true
+			break
+		}
+	}
+
+	if !granted {
+		exists, err := m.enforcer.HasRule(ctx, rule)
+		if err != nil {
+			return Grant{}, err
+		}
+		if exists {
+			return Grant{}, fmt.Errorf("grant %q: the rule already exists without expiry", rule.String())
+		}
+	}
+
+	now := m.now()
+	grant := Grant{ID: id, Rule: rule, GrantedAt: now, ExpiresAt: now.Add(ttl)}
+	// The grant is stored before the rule is added, so that a crash in between never leaves a rule without expiry.
+	if err := m.opts.Store.Put(ctx, grant); err != nil {
+		return Grant{}, err
+	}
+	if !granted {
+		if _, err := m.enforcer.AddRule(ctx, rule); err != nil {
+			if deleteErr := m.opts.Store.Delete(ctx, id); deleteErr != nil {
+				return Grant{}, fmt.Errorf("%w, and the grant could not be removed from the store: %v", err, deleteErr)
+			}
+			return Grant{}, err
+		}
+	}
+	m.emit(GrantEvent{Type: GrantEventGranted, Grant: grant})
+	return grant, nil
+}
+
+// Grants returns the grants that have not been revoked yet.
+func (m *GrantManager) Grants(ctx context.Context) ([]Grant, error) {
+	return m.opts.Store.List(ctx)
+}
+
+// Revoke removes the rule of the grant with the given ID before its expiry.
+func (m *GrantManager) Revoke(ctx context.Context, id string) error {
+	grants, err := m.opts.Store.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, grant := range grants {
+		if grant.ID == id {
+			if err := m.revoke(ctx, grant); err != nil {
+				return err
+			}
+			m.emit(GrantEvent{Type: GrantEventRevoked, Grant: grant})
+			return nil
+		}
+	}
+	return fmt.Errorf("no grant %q", id)
+}
+
+// Reap revokes the grants that have expired, and returns them.
+// Grants that cannot be revoked are reported with a GrantEventError event and kept for the next reap.
+func (m *GrantManager) Reap(ctx context.Context) ([]Grant, error) {
+	grants, err := m.opts.Store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := m.now()
+	var expired []Grant
+	for _, grant := range grants {
+		if grant.ExpiresAt.After(now) {
+			continue
+		}
+		if err := m.revoke(ctx, grant); err != nil {
+			m.emit(GrantEvent{Type: GrantEventError, Grant: grant, Err: err})
+			continue
+		}
+		expired = append(expired, grant)
+		m.emit(GrantEvent{Type: GrantEventExpired, Grant: grant})
+	}
+	return expired, nil
+}
+
+func (m *GrantManager) revoke(ctx context.Context, grant Grant) error {
+	if _, err := m.enforcer.RemoveRule(ctx, grant.Rule); err != nil {
+		return err
+	}
+	return m.opts.Store.Delete(ctx, grant.ID)
+}
+
+// Start reaps the expired grants now and then every GrantOptions.ReapInterval, in the background,
+// until ctx is done or Close is called. Errors of the store are reported as GrantEventError events.
+func (m *GrantManager) Start(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stop != nil {
+		return
+	}
+	ctx, m.stop = context.WithCancel(ctx)
+	m.done = make(chan struct{})
+	go m.run(ctx, m.done)
+}
+
+func (m *GrantManager) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(m.opts.ReapInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := m.Reap(ctx); err != nil && ctx.Err() == nil {
+			m.emit(GrantEvent{Type: GrantEventError, Err: err})
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close stops the background reaping started by Start, and waits for it to finish.
+func (m *GrantManager) Close() error {
+	m.mu.Lock()
+	stop, done := m.stop, m.done
+	m.stop, m.done = nil, nil
+	m.mu.Unlock()
+	if stop != nil {
+		stop()
+		<-done
+	}
+	return nil
+}
+
+func (m *GrantManager) emit(event GrantEvent) {
+	if m.opts.OnEvent != nil {
+		m.opts.OnEvent(event)
+	}
+}
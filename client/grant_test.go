@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGrantManager(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	store := NewFileGrantStore(filepath.Join(t.TempDir(), "grants.json"))
+	var events []GrantEvent
+	grants, err := NewGrantManager(e, GrantOptions{Store: store, OnEvent: func(event GrantEvent) {
+		events = append(events, event)
+	}})
+	if err != nil {
+		t.Fatalf("NewGrantManager err: %v", err)
+	}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	grants.now = func() time.Time { return now }
+
+	if _, err := grants.GrantRoleFor(ctx, "alice", "oncall", time.Hour); err != nil {
+		t.Fatalf("GrantRoleFor err: %v", err)
+	}
+	if _, err := grants.GrantPermissionFor(ctx, "bob", 2*time.Hour, "data1", "read"); err != nil {
+		t.Fatalf("GrantPermissionFor err: %v", err)
+	}
+	if ok, err := e.AddRoleForUser(ctx, "carol", "admin"); !ok || err != nil {
+		t.Fatalf("AddRoleForUser = %v, %v", ok, err)
+	}
+	if _, err := grants.GrantRoleFor(ctx, "carol", "admin", time.Hour); err == nil {
+		t.Errorf("GrantRoleFor accepted a permanent rule")
+	}
+
+	// A new manager on the same store, as after a restart, revokes the expired grants.
+	now = now.Add(90 * time.Minute)
+	restarted, err := NewGrantManager(e, GrantOptions{Store: store, OnEvent: grants.opts.OnEvent})
+	if err != nil {
+		t.Fatalf("NewGrantManager err: %v", err)
+	}
+	restarted.now = grants.now
+	expired, err := restarted.Reap(ctx)
+	if err != nil || len(expired) != 1 || expired[0].ID != `["g","alice","oncall"]` {
+		t.Fatalf("Reap = %v, %v", expired, err)
+	}
+	if ok, err := e.HasRoleForUser(ctx, "alice", "oncall"); ok || err != nil {
+		t.Errorf("HasRoleForUser = %v, %v after expiry", ok, err)
+	}
+	if ok, err := e.HasPermissionForUser(ctx, "bob", "data1", "read"); !ok || err != nil {
+		t.Errorf("HasPermissionForUser = %v, %v before expiry", ok, err)
+	}
+	if left, err := store.List(ctx); err != nil || len(left) != 1 {
+		t.Errorf("List = %v, %v", left, err)
+	}
+
+	if len(events) != 3 || events[0].Type != GrantEventGranted || events[2].Type != GrantEventExpired {
+		t.Errorf("events = %v", events)
+	}
+}
+
+func TestGrantManagerStart(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	expired := make(chan Grant, 1)
+	grants, err := NewGrantManager(e, GrantOptions{
+		Store:        NewFileGrantStore(filepath.Join(t.TempDir(), "grants.json")),
+		ReapInterval: 10 * time.Millisecond,
+		OnEvent: func(event GrantEvent) {
+			if event.Type == GrantEventExpired {
+				expired <- event.Grant
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewGrantManager err: %v", err)
+	}
+	if _, err := grants.GrantRoleFor(ctx, "alice", "oncall", 50*time.Millisecond); err != nil {
+		t.Fatalf("GrantRoleFor err: %v", err)
+	}
+	grants.Start(ctx)
+	done := grants.done
+
+	select {
+	case grant := <-expired:
+		if grant.Rule.String() != "g, alice, oncall" {
+			t.Errorf("expired grant: %v", grant)
+		}
+	case <-ctx.Done():
+		t.Fatalf("the grant was not revoked in the background")
+	}
+	if ok, err := e.HasRoleForUser(ctx, "alice", "oncall"); ok || err != nil {
+		t.Errorf("HasRoleForUser = %v, %v after expiry", ok, err)
+	}
+
+	if err := grants.Close(); err != nil {
+		t.Fatalf("Close err: %v", err)
+	}
+	select {
+	case <-done:
+	default:
+		t.Errorf("Close returned before the background reaping stopped")
+	}
+}
+
+func TestGrantManagerDefaultStore(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	grants, err := NewGrantManager(e, GrantOptions{})
+	if err != nil {
+		t.Fatalf("NewGrantManager err: %v", err)
+	}
+	grant, err := grants.GrantRoleFor(ctx, "alice", "oncall", time.Hour)
+	if err != nil {
+		t.Fatalf("GrantRoleFor err: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, DefaultGrantStorePath)); err != nil {
+		t.Errorf("the default store was not written: %v", err)
+	}
+
+	restarted, err := NewGrantManager(e, GrantOptions{})
+	if err != nil {
+		t.Fatalf("NewGrantManager err: %v", err)
+	}
+	kept, err := restarted.Grants(ctx)
+	if err != nil || len(kept) != 1 || kept[0].ID != grant.ID {
+		t.Errorf("Grants after a restart = %v, %v", kept, err)
+	}
+}
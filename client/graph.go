@@ -0,0 +1,211 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Graph node kinds, reported in GraphNode.Kind.
+const (
+	NodeUser = "user"
+	NodeRole = "role"
+)
+
+// RoleGraph is the role hierarchy described by role inheritance rules.
+type RoleGraph struct {
+	// Nodes are the users and roles of the rules, sorted by ID.
+	Nodes []GraphNode `json:"nodes"`
+	// Edges go from a user to a role it holds, in the order of the rules.
+	Edges []GraphEdge `json:"edges"`
+	// Domains are the domains of the rules, sorted.
+	Domains []string `json:"domains"`
+}
+
+// GraphNode is a user or role of a RoleGraph.
+type GraphNode struct {
+	ID string `json:"id"`
+	// Kind is NodeRole if some rule grants the node to another one, NodeUser otherwise.
+	Kind string `json:"kind"`
+	// Permissions are the authorization rules whose subject is the node.
+	Permissions []Rule `json:"permissions,omitempty"`
+}
+
+// GraphEdge is a role inheritance rule of a RoleGraph.
+type GraphEdge struct {
+	PType  string `json:"ptype"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Domain string `json:"domain,omitempty"`
+}
+
+// RoleGraph builds the role hierarchy of the role inheritance rules of ptypes, "g" by default,
+// with the authorization rules granted to each user and role.
+func (e *Enforcer) RoleGraph(ctx context.Context, ptypes ...string) (*RoleGraph, error) {
+	if len(ptypes) == 0 {
+		ptypes = []string{"g"}
+	}
+	var grouping []Rule
+	for _, ptype := range ptypes {
+		if !isGroupingPType(ptype) {
+			return nil, fmt.Errorf("ptype %q is not a role inheritance ptype", ptype)
+		}
+		rules, err := e.GetNamedGroupingPolicyRules(ctx, ptype)
+		if err != nil {
+			return nil, err
+		}
+		grouping = append(grouping, rules...)
+	}
+
+	var policyPTypes []string
+	for _, ptype := range e.ptypes() {
+		if !isGroupingPType(ptype) {
+			policyPTypes = append(policyPTypes, ptype)
+		}
+	}
+	policy, err := e.getRules(ctx, policyPTypes)
+	if err != nil {
+		return nil, err
+	}
+	return buildRoleGraph(grouping, policy), nil
+}
+
+func buildRoleGraph(grouping, policy []Rule) *RoleGraph {
+	g := &RoleGraph{Nodes: []GraphNode{}, Edges: []GraphEdge{}, Domains: []string{}}
+	kinds := make(map[string]string)
+	domains := make(map[string]bool)
+	for _, rule := range grouping {
+		if len(rule.Values) < 2 {
+			continue
+		}
+		edge := GraphEdge{PType: rule.PType, From: rule.Values[0], To: rule.Values[1]}
+		if len(rule.Values) > 2 {
+			edge.Domain = rule.Values[2]
+			domains[edge.Domain] = true
+		}
+		g.Edges = append(g.Edges, edge)
+		if _, ok := kinds[edge.From]; !ok {
+			kinds[edge.From] = NodeUser
+		}
+		kinds[edge.To] = NodeRole
+	}
+
+	permissions := make(map[string][]Rule)
+	for _, rule := range policy {
+		if len(rule.Values) > 0 {
+			if _, ok := kinds[rule.Values[0]]; ok {
+				permissions[rule.Values[0]] = append(permissions[rule.Values[0]], rule)
+			}
+		}
+	}
+
+	for id, kind := range kinds {
+		g.Nodes = append(g.Nodes, GraphNode{ID: id, Kind: kind, Permissions: permissions[id]})
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+	g.Domains = append(g.Domains, sortedKeys(domains)...)
+	return g
+}
+
+// WriteJSON writes the graph to w as indented JSON.
+func (g *RoleGraph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(g)
+}
+
+// WriteDOT writes the graph to w in the Graphviz DOT language.
+// Users are drawn as ellipses and roles as boxes listing their permissions,
+// and the edges of rules with a domain are labeled with it.
+func (g *RoleGraph) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph roles {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	for _, node := range g.Nodes {
+		shape := "ellipse"
+		if node.Kind == NodeRole {
+			shape = "box"
+		}
+		fmt.Fprintf(bw, "  %s [shape=%s, label=%s];\n", strconv.Quote(node.ID), shape, strconv.Quote(nodeLabel(node, "\n")))
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(bw, "  %s -> %s", strconv.Quote(edge.From), strconv.Quote(edge.To))
+		if label := edgeLabel(edge); label != "" {
+			fmt.Fprintf(bw, " [label=%s]", strconv.Quote(label))
+		}
+		fmt.Fprintln(bw, ";")
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// WriteMermaid writes the graph to w as a Mermaid flowchart.
+// Users are drawn as rounded nodes and roles as rectangles listing their permissions,
+// and the edges of rules with a domain are labeled with it.
+func (g *RoleGraph) WriteMermaid(w io.Writer) error {
+	ids := make(map[string]string, len(g.Nodes))
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "flowchart LR")
+	for i, node := range g.Nodes {
+		ids[node.ID] = "n" + strconv.Itoa(i)
+		label := mermaidEscape(nodeLabel(node, "<br/>"))
+		if node.Kind == NodeRole {
+			fmt.Fprintf(bw, "  %s[\"%s\"]\n", ids[node.ID], label)
+		} else {
+			fmt.Fprintf(bw, "  %s([\"%s\"])\n", ids[node.ID], label)
+		}
+	}
+	for _, edge := range g.Edges {
+		if label := edgeLabel(edge); label != "" {
+			fmt.Fprintf(bw, "  %s -->|\"%s\"| %s\n", ids[edge.From], mermaidEscape(label), ids[edge.To])
+		} else {
+			fmt.Fprintf(bw, "  %s --> %s\n", ids[edge.From], ids[edge.To])
+		}
+	}
+	return bw.Flush()
+}
+
+// nodeLabel returns the ID of node followed by its permissions, without their subject, one per line.
+func nodeLabel(node GraphNode, newline string) string {
+	lines := []string{node.ID}
+	for _, rule := range node.Permissions {
+		line := strings.Join(rule.Values[1:], " ")
+		if rule.PType != "p" {
+			line = rule.PType + ": " + line
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, newline)
+}
+
+// edgeLabel returns the domain of edge, prefixed with its ptype if it is not "g".
+func edgeLabel(edge GraphEdge) string {
+	label := edge.Domain
+	if edge.PType != "g" {
+		label = strings.TrimSpace(edge.PType + " " + label)
+	}
+	return label
+}
+
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRoleGraph(t *testing.T) {
+	g := buildRoleGraph([]Rule{
+		NewRule("g", "alice", "data2_admin"),
+		NewRule("g", "george", "data3_admin"),
+		NewRule("g", "data3_admin", "data4_admin"),
+	}, []Rule{
+		NewRule("p", "alice", "data1", "read"),
+		NewRule("p", "data2_admin", "data2", "read"),
+		NewRule("p", "data4_admin", "data4", "read"),
+		NewRule("p", "bob", "data2", "write"),
+	})
+
+	if len(g.Nodes) != 5 || len(g.Edges) != 3 || len(g.Domains) != 0 {
+		t.Fatalf("graph = %+v", g)
+	}
+	for _, node := range g.Nodes {
+		switch node.ID {
+		case "alice", "george":
+			if node.Kind != NodeUser {
+				t.Errorf("%s is a %s, supposed to be a user", node.ID, node.Kind)
+			}
+		default:
+			if node.Kind != NodeRole {
+				t.Errorf("%s is a %s, supposed to be a role", node.ID, node.Kind)
+			}
+		}
+	}
+
+	var dot, mermaid bytes.Buffer
+	if err := g.WriteDOT(&dot); err != nil {
+		t.Fatalf("WriteDOT err: %v", err)
+	}
+	if !strings.Contains(dot.String(), `"data3_admin" -> "data4_admin";`) ||
+		!strings.Contains(dot.String(), `"data4_admin" [shape=box, label="data4_admin\ndata4 read"];`) {
+		t.Errorf("WriteDOT:\n%s", dot.String())
+	}
+	if err := g.WriteMermaid(&mermaid); err != nil {
+		t.Fatalf("WriteMermaid err: %v", err)
+	}
+	if !strings.Contains(mermaid.String(), `n0(["alice<br/>data1 read"])`) || !strings.Contains(mermaid.String(), "n2 --> n3") {
+		t.Errorf("WriteMermaid:\n%s", mermaid.String())
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// DefaultPageSize is the number of rules returned per page by a PolicyIterator.
+const DefaultPageSize = 100
+
+// IteratorOptions controls how a PolicyIterator pages through a policy.
+type IteratorOptions struct {
+	// PageSize is the number of rules per page. Defaults to DefaultPageSize.
+	PageSize int
+	// PageToken resumes iteration after the page that returned it, see PolicyIterator.PageToken.
+	PageToken string
+}
+
+// PolicyIterator pages through the rules of a ptype without fetching the whole policy in one message.
+//
+//	it := e.NewPolicyIterator("p", client.IteratorOptions{})
+//	for it.Next(ctx) {
+//		for _, rule := range it.Rules() {
+//			...
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// Rules are fetched by key, the subject for policy rules and the role for role inheritance rules,
+// with one filtered query per key, as casbin-server filters on a single value per field.
+// Each message therefore holds the rules of one key: it is bounded by the number of rules of the largest key,
+// not by the size of the policy. Every page holds PageSize rules except the last one, the rules of a key
+// being split across pages when needed.
+//
+// Rules whose key field is empty cannot be isolated by a filtered query, which matches every rule
+// for an empty value, so the iteration fails when it reaches them.
+type PolicyIterator struct {
+	e          *Enforcer
+	ptype      string
+	fieldIndex int32
+	pageSize   int
+	start      pageToken
+
+	keys []string
+	next int
+	// pending are the rules of key not returned yet, emitted the number returned.
+	key     string
+	pending []Rule
+	emitted int
+
+	rules []Rule
+	token string
+	err   error
+}
+
+// pageToken is the position of the iteration: after the first Skip rules of Key, or after Key if Skip is 0.
+type pageToken struct {
+	Key  string `json:"key"`
+	Skip int    `json:"skip,omitempty"`
+}
+
+// NewPolicyIterator creates an iterator over the rules of ptype, e.g. "p" or "g2".
+func (e *Enforcer) NewPolicyIterator(ptype string, opts IteratorOptions) *PolicyIterator {
+	it := &PolicyIterator{e: e, ptype: ptype, pageSize: opts.PageSize}
+	if it.pageSize <= 0 {
+		it.pageSize = DefaultPageSize
+	}
+	// The keys are those of GetAllNamedRoles and GetAllNamedSubjects.
+	if isGroupingPType(ptype) {
+		it.fieldIndex = 1
+	} else if index, ok := e.fieldIndex(ptype, "sub"); ok {
+		it.fieldIndex = int32(index)
+	}
+	if opts.PageToken != "" {
+		data, err := base64.RawURLEncoding.DecodeString(opts.PageToken)
+		if err == nil {
+			err = json.Unmarshal(data, &it.start)
+		}
+		if err != nil {
+			it.err = fmt.Errorf("invalid page token: %w", err)
+		}
+	}
+	return it
+}
+
+// Next fetches the next page of rules, and reports whether there was one.
+func (it *PolicyIterator) Next(ctx context.Context) bool {
+	it.rules = nil
+	if it.err != nil {
+		return false
+	}
+	if it.keys == nil && !it.loadKeys(ctx) {
+		return false
+	}
+
+	for len(it.rules) < it.pageSize {
+		if len(it.pending) == 0 {
+			if it.next >= len(it.keys) {
+				break
+			}
+			if !it.fetch(ctx) {
+				return false
+			}
+			continue
+		}
+		n := it.pageSize - len(it.rules)
+		if n > len(it.pending) {
+			n = len(it.pending)
+		}
+		it.rules = append(it.rules, it.pending[:n]...)
+		it.pending = it.pending[n:]
+		it.emitted += n
+	}
+
+	it.token = ""
+	if len(it.pending) > 0 {
+		it.token = encodePageToken(pageToken{Key: it.key, Skip: it.emitted})
+	} else if it.next < len(it.keys) {
+		it.token = encodePageToken(pageToken{Key: it.key})
+	}
+	return len(it.rules) > 0
+}
+
+// Rules returns the rules of the current page.
+func (it *PolicyIterator) Rules() []Rule {
+	return it.rules
+}
+
+// PageToken returns a token that resumes iteration after the current page,
+// or "" if the current page is the last one.
+func (it *PolicyIterator) PageToken() string {
+	return it.token
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *PolicyIterator) Err() error {
+	return it.err
+}
+
+func encodePageToken(token pageToken) string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// loadKeys lists the sorted keys of the ptype, starting at the page token.
+func (it *PolicyIterator) loadKeys(ctx context.Context) bool {
+	var keys []string
+	var err error
+	if isGroupingPType(it.ptype) {
+		keys, err = it.e.GetAllNamedRoles(ctx, it.ptype)
+	} else {
+		keys, err = it.e.GetAllNamedSubjects(ctx, it.ptype)
+	}
+	if err != nil {
+		it.err = err
+		return false
+	}
+	sort.Strings(keys)
+	if it.start != (pageToken{}) {
+		keys = keys[sort.Search(len(keys), func(i int) bool {
+			if it.start.Skip > 0 {
+				return keys[i] >= it.start.Key
+			}
+			return keys[i] > it.start.Key
+		}):]
+	}
+	it.keys = keys
+	if it.keys == nil {
+		it.keys = []string{}
+	}
+	return true
+}
+
+// fetch gets the rules of the next key into pending.
+func (it *PolicyIterator) fetch(ctx context.Context) bool {
+	key := it.keys[it.next]
+	if key == "" {
+		it.err = fmt.Errorf("rules of %s with an empty field %d cannot be fetched by key", it.ptype, it.fieldIndex)
+		return false
+	}
+
+	var rules []Rule
+	var err error
+	if isGroupingPType(it.ptype) {
+		rules, err = it.e.GetFilteredNamedGroupingPolicyRules(ctx, it.ptype, it.fieldIndex, key)
+	} else {
+		rules, err = it.e.GetFilteredNamedPolicyRules(ctx, it.ptype, it.fieldIndex, key)
+	}
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.next++
+	it.key, it.pending, it.emitted = key, rules, 0
+	if key == it.start.Key && it.start.Skip > 0 {
+		skip := it.start.Skip
+		if skip > len(rules) {
+			skip = len(rules)
+		}
+		it.pending, it.emitted = rules[skip:], skip
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPolicyIterator(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("p", "alice", "data1", "read"),
+		NewRule("p", "alice", "data1", "write"),
+		NewRule("p", "bob", "data2", "write"),
+		NewRule("p", "carol", "data3", "read"),
+		NewRule("g", "alice", "admin"),
+		NewRule("g", "bob", "admin"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+
+	// Pages count rules: the rules of alice are split across the first two pages.
+	it := e.NewPolicyIterator("p", IteratorOptions{PageSize: 1})
+	if !it.Next(ctx) {
+		t.Fatalf("Next() = false, err: %v", it.Err())
+	}
+	if len(it.Rules()) != 1 || it.Rules()[0].String() != "p, alice, data1, read" || it.PageToken() == "" {
+		t.Fatalf("first page: %v, token %q", it.Rules(), it.PageToken())
+	}
+
+	resumed := e.NewPolicyIterator("p", IteratorOptions{PageSize: 2, PageToken: it.PageToken()})
+	if !resumed.Next(ctx) {
+		t.Fatalf("Next() = false, err: %v", resumed.Err())
+	}
+	if len(resumed.Rules()) != 2 || resumed.Rules()[0].String() != "p, alice, data1, write" || resumed.Rules()[1].Sub() != "bob" {
+		t.Fatalf("resumed page: %v", resumed.Rules())
+	}
+	if !resumed.Next(ctx) || len(resumed.Rules()) != 1 || resumed.Rules()[0].Sub() != "carol" || resumed.PageToken() != "" {
+		t.Errorf("last page: %v, token %q, err: %v", resumed.Rules(), resumed.PageToken(), resumed.Err())
+	}
+	if resumed.Next(ctx) || resumed.Err() != nil {
+		t.Errorf("Next() after the last page = true, err: %v", resumed.Err())
+	}
+
+	count := 0
+	groupings := e.NewPolicyIterator("g", IteratorOptions{})
+	for groupings.Next(ctx) {
+		count += len(groupings.Rules())
+	}
+	if groupings.Err() != nil || count != 2 {
+		t.Errorf("iterated %d role inheritance rules, err: %v", count, groupings.Err())
+	}
+
+	if _, err := e.AddPolicy(ctx, "", "data4", "read"); err != nil {
+		t.Fatalf("AddPolicy err: %v", err)
+	}
+	empty := e.NewPolicyIterator("p", IteratorOptions{})
+	if empty.Next(ctx) || empty.Err() == nil {
+		t.Errorf("Next() with an empty subject = %v, err: %v", empty.Rules(), empty.Err())
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/casbin/casbin/v2/util"
+)
+
+// Lint checks, reported in LintIssue.Check.
+const (
+	LintDuplicate              = "duplicate"
+	LintArity                  = "arity"
+	LintRoleCycle              = "role-cycle"
+	LintRoleWithoutUsers       = "role-without-users"
+	LintRoleWithoutPermissions = "role-without-permissions"
+	LintShadowed               = "shadowed"
+)
+
+// Lint severities, reported in LintIssue.Severity.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// LintIssue is a problem found in the policy by Enforcer.Lint.
+type LintIssue struct {
+	Check    string `json:"check"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	// Rules are the rules involved in the issue.
+	Rules []Rule `json:"rules,omitempty"`
+	// Subject is the user or role the issue is about, if any.
+	Subject string `json:"subject,omitempty"`
+}
+
+// LintReport lists the issues found in the policy by Enforcer.Lint.
+type LintReport struct {
+	Issues []LintIssue `json:"issues"`
+}
+
+// HasErrors reports whether the report holds an issue of severity SeverityError.
+func (r *LintReport) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteJSON writes the report to w as indented JSON.
+func (r *LintReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+func (r *LintReport) add(check, severity string, subject string, rules []Rule, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, LintIssue{
+		Check:    check,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+		Rules:    rules,
+		Subject:  subject,
+	})
+}
+
+// LintOptions controls the checks of Enforcer.LintWithOptions.
+type LintOptions struct {
+	// IsRole tells whether a policy subject is a role rather than a user.
+	// By default, a subject is a role if it is the role of a "g" rule.
+	IsRole func(subject string) bool
+}
+
+// Lint checks every rule of the policy and reports:
+//   - duplicate rules,
+//   - rules whose number of fields does not match the model,
+//   - cycles in role inheritance rules, e.g. "g, a, b" and "g, b, a",
+//   - rules granted to roles that no user ultimately holds,
+//   - roles that grant no permission, directly or through inherited roles,
+//   - rules made redundant by a broader rule using "*" wildcards, as matched by keyMatch.
+func (e *Enforcer) Lint(ctx context.Context) (*LintReport, error) {
+	return e.LintWithOptions(ctx, LintOptions{})
+}
+
+// LintWithOptions is Lint with options.
+func (e *Enforcer) LintWithOptions(ctx context.Context, opts LintOptions) (*LintReport, error) {
+	ptypes := e.ptypes()
+	rules, err := e.getRules(ctx, ptypes)
+	if err != nil {
+		return nil, err
+	}
+	arity := make(map[string]int)
+	for _, ptype := range ptypes {
+		if n, ok := e.arity(ptype); ok {
+			arity[ptype] = n
+		}
+	}
+	sub, ok := e.fieldIndex("p", "sub")
+	if !ok {
+		sub = -1
+	}
+	return lintRules(rules, arity, sub, opts), nil
+}
+
+// arity returns the number of fields of ptype declared by the model.
+func (e *Enforcer) arity(ptype string) (int, bool) {
+	if e.model == nil {
+		return 0, false
+	}
+	sec := "p"
+	if isGroupingPType(ptype) {
+		sec = "g"
+	}
+	ast, ok := e.model[sec][ptype]
+	if !ok {
+		return 0, false
+	}
+	return len(ast.Tokens), true
+}
+
+// lintRules runs the checks of Lint on rules, given the arity of each ptype known from the model
+// and the position of the subject in "p" rules, or -1 if the model has none.
+func lintRules(rules []Rule, arity map[string]int, sub int, opts LintOptions) *LintReport {
+	report := &LintReport{Issues: []LintIssue{}}
+
+	seen := make(map[string]bool, len(rules))
+	byPType := make(map[string][]Rule)
+	var ptypes []string
+	for _, rule := range rules {
+		if seen[rule.key()] {
+			report.add(LintDuplicate, SeverityWarning, "", []Rule{rule}, "rule %q is duplicated", rule.String())
+			continue
+		}
+		seen[rule.key()] = true
+		if _, ok := byPType[rule.PType]; !ok {
+			ptypes = append(ptypes, rule.PType)
+		}
+		byPType[rule.PType] = append(byPType[rule.PType], rule)
+
+		if n, ok := arity[rule.PType]; ok {
+			if (!rule.IsGrouping() && len(rule.Values) != n) || (rule.IsGrouping() && len(rule.Values) < n) {
+				report.add(LintArity, SeverityError, "", []Rule{rule},
+					"rule %q has %d fields, the model declares %d", rule.String(), len(rule.Values), n)
+			}
+		}
+	}
+
+	for _, ptype := range ptypes {
+		if !isGroupingPType(ptype) {
+			continue
+		}
+		for _, cycle := range newRoleIndex(byPType[ptype]).cycles() {
+			cycleRules := make([]Rule, len(cycle))
+			names := make([]string, len(cycle))
+			for i, edge := range cycle {
+				cycleRules[i] = edge.rule
+				names[i] = edge.user + " -> " + edge.role
+			}
+			report.add(LintRoleCycle, SeverityError, cycle[0].user, cycleRules,
+				"role inheritance cycle: %s", strings.Join(names, ", "))
+		}
+	}
+
+	lintRoles(report, byPType, sub, opts)
+
+	for _, ptype := range ptypes {
+		if !isGroupingPType(ptype) {
+			lintShadowed(report, byPType[ptype])
+		}
+	}
+	return report
+}
+
+// lintRoles reports the roles of "g" that have no users or no permissions in the "p" policy,
+// whose subject is field subIndex. Rules too short to have a subject are skipped.
+func lintRoles(report *LintReport, byPType map[string][]Rule, subIndex int, opts LintOptions) {
+	ri := newRoleIndex(byPType["g"])
+	isRole := opts.IsRole
+	if isRole == nil {
+		isRole = ri.isRole
+	}
+
+	bySubject := make(map[string][]Rule)
+	var subjects []string
+	for _, rule := range byPType["p"] {
+		if subIndex < 0 || subIndex >= len(rule.Values) {
+			continue
+		}
+		sub := rule.Values[subIndex]
+		if _, ok := bySubject[sub]; !ok {
+			subjects = append(subjects, sub)
+		}
+		bySubject[sub] = append(bySubject[sub], rule)
+	}
+
+	for _, sub := range subjects {
+		if !isRole(sub) {
+			continue
+		}
+		hasUser := false
+		for _, user := range ri.users(sub, "", true) {
+			if !isRole(user) {
+				hasUser = true
+				break
+			}
+		}
+		if !hasUser {
+			report.add(LintRoleWithoutUsers, SeverityWarning, sub, bySubject[sub],
+				"role %q is granted %d rules but no user holds it", sub, len(bySubject[sub]))
+		}
+	}
+
+	for _, role := range ri.allRoles() {
+		if len(bySubject[role]) > 0 {
+			continue
+		}
+		granted := false
+		for _, inherited := range ri.roles(role, "", true) {
+			if len(bySubject[inherited]) > 0 {
+				granted = true
+				break
+			}
+		}
+		if !granted {
+			report.add(LintRoleWithoutPermissions, SeverityWarning, role, nil,
+				"role %q grants no permission", role)
+		}
+	}
+}
+
+// lintShadowed reports the rules of a single ptype that are matched by a broader wildcard rule.
+func lintShadowed(report *LintReport, rules []Rule) {
+	var wildcards []Rule
+	for _, rule := range rules {
+		for _, value := range rule.Values {
+			if strings.Contains(value, "*") {
+				wildcards = append(wildcards, rule)
+				break
+			}
+		}
+	}
+
+	for _, rule := range rules {
+		for _, wildcard := range wildcards {
+			if !rule.Equal(wildcard) && covers(wildcard.Values, rule.Values) {
+				report.add(LintShadowed, SeverityInfo, "", []Rule{rule, wildcard},
+					"rule %q is shadowed by %q", rule.String(), wildcard.String())
+				break
+			}
+		}
+	}
+}
+
+// covers reports whether every field of broad matches the same field of narrow, with keyMatch.
+func covers(broad, narrow []string) bool {
+	if len(broad) != len(narrow) {
+		return false
+	}
+	for i := range broad {
+		if !util.KeyMatch(narrow[i], broad[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLintRules(t *testing.T) {
+	rules := []Rule{
+		NewRule("p", "alice", "data1", "read"),
+		NewRule("p", "alice", "data1", "read"),
+		NewRule("p", "bob", "data2"),
+		NewRule("p", "data2_admin", "data2", "read"),
+		NewRule("p", "cycle_a", "data3", "read"),
+		NewRule("p", "carol", "/files/*", "read"),
+		NewRule("p", "carol", "/files/report", "read"),
+		NewRule("g", "alice", "data2_admin"),
+		NewRule("g", "cycle_a", "cycle_b"),
+		NewRule("g", "cycle_b", "cycle_a"),
+		NewRule("g", "dave", "empty_role"),
+	}
+	report := lintRules(rules, map[string]int{"p": 3, "g": 2}, 0, LintOptions{})
+
+	want := map[string]string{
+		LintDuplicate:              "alice, data1, read",
+		LintArity:                  "bob, data2",
+		LintRoleCycle:              "cycle_a -> cycle_b",
+		LintRoleWithoutUsers:       "cycle_a",
+		LintRoleWithoutPermissions: "empty_role",
+		LintShadowed:               "/files/report",
+	}
+	found := make(map[string]bool)
+	for _, issue := range report.Issues {
+		if substr, ok := want[issue.Check]; ok && strings.Contains(issue.Message, substr) {
+			found[issue.Check] = true
+		}
+	}
+	for check := range want {
+		if !found[check] {
+			t.Errorf("no %s issue in %+v", check, report.Issues)
+		}
+	}
+	if !report.HasErrors() {
+		t.Errorf("HasErrors() = false")
+	}
+
+	clean := lintRules([]Rule{
+		NewRule("p", "data2_admin", "data2", "read"),
+		NewRule("g", "alice", "data2_admin"),
+	}, map[string]int{"p": 3, "g": 2}, 0, LintOptions{})
+	if len(clean.Issues) != 0 {
+		t.Errorf("issues in a clean policy: %+v", clean.Issues)
+	}
+}
+
+func TestLintRulesSubjectIndex(t *testing.T) {
+	// With "p = obj, sub, act", the role data2_admin is the second field.
+	report := lintRules([]Rule{
+		NewRule("p", "data2", "data2_admin", "read"),
+		NewRule("g", "alice", "data2_admin"),
+		NewRule("g", "bob", "empty_role"),
+	}, map[string]int{"p": 3, "g": 2}, 1, LintOptions{})
+	if len(report.Issues) != 1 || report.Issues[0].Check != LintRoleWithoutPermissions || report.Issues[0].Subject != "empty_role" {
+		t.Errorf("issues: %+v", report.Issues)
+	}
+}
@@ -16,6 +16,10 @@ package client
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
 
 	pb "github.com/casbin/casbin-server/proto"
 )
@@ -24,10 +28,14 @@ import (
 // If the rule already exists, the function returns false and the rule will not be added.
 // Otherwise the function returns true by adding the new rule.
 func (e *Enforcer) AddPolicy(ctx context.Context, params ...interface{}) (bool, error) {
+	rule, err := paramsToStrSlice(params)
+	if err != nil {
+		return false, err
+	}
 	res, err := e.client.remoteClient.AddPolicy(ctx, &pb.PolicyRequest{
 		EnforcerHandler: e.handler,
 		PType:           "p",
-		Params:          paramsToStrSlice(params),
+		Params:          rule,
 	})
 	if err != nil {
 		return false, err
@@ -39,10 +47,14 @@ func (e *Enforcer) AddPolicy(ctx context.Context, params ...interface{}) (bool,
 // If the rule already exists, the function returns false and the rule will not be added.
 // Otherwise the function returns true by adding the new rule.
 func (e *Enforcer) AddNamedPolicy(ctx context.Context, ptype string, params ...interface{}) (bool, error) {
+	rule, err := paramsToStrSlice(params)
+	if err != nil {
+		return false, err
+	}
 	res, err := e.client.remoteClient.AddNamedPolicy(ctx, &pb.PolicyRequest{
 		EnforcerHandler: e.handler,
 		PType:           ptype,
-		Params:          paramsToStrSlice(params),
+		Params:          rule,
 	})
 	if err != nil {
 		return false, err
@@ -52,10 +64,14 @@ func (e *Enforcer) AddNamedPolicy(ctx context.Context, ptype string, params ...i
 
 // RemovePolicy removes an authorization rule from the current policy.
 func (e *Enforcer) RemovePolicy(ctx context.Context, params ...interface{}) (bool, error) {
+	rule, err := paramsToStrSlice(params)
+	if err != nil {
+		return false, err
+	}
 	res, err := e.client.remoteClient.RemovePolicy(ctx, &pb.PolicyRequest{
 		EnforcerHandler: e.handler,
 		PType:           "p",
-		Params:          paramsToStrSlice(params),
+		Params:          rule,
 	})
 	if err != nil {
 		return false, err
@@ -65,10 +81,14 @@ func (e *Enforcer) RemovePolicy(ctx context.Context, params ...interface{}) (boo
 
 // RemoveNamedPolicy removes an authorization rule from the current named policy.
 func (e *Enforcer) RemoveNamedPolicy(ctx context.Context, ptype string, params ...interface{}) (bool, error) {
+	rule, err := paramsToStrSlice(params)
+	if err != nil {
+		return false, err
+	}
 	res, err := e.client.remoteClient.RemoveNamedPolicy(ctx, &pb.PolicyRequest{
 		EnforcerHandler: e.handler,
 		PType:           ptype,
-		Params:          paramsToStrSlice(params),
+		Params:          rule,
 	})
 	if err != nil {
 		return false, err
@@ -156,11 +176,19 @@ func (e *Enforcer) GetFilteredNamedPolicy(ctx context.Context, ptype string, fie
 // AddGroupingPolicy adds a role inheritance rule to the current policy.
 // If the rule already exists, the function returns false and the rule will not be added.
 // Otherwise the function returns true by adding the new rule.
+// It fails with a *ConstraintError if the rule would break a constraint registered with AddConstraint.
 func (e *Enforcer) AddGroupingPolicy(ctx context.Context, params ...interface{}) (bool, error) {
+	rule, err := paramsToStrSlice(params)
+	if err != nil {
+		return false, err
+	}
+	if err := e.checkConstraints(ctx, rule); err != nil {
+		return false, err
+	}
 	res, err := e.client.remoteClient.AddGroupingPolicy(ctx, &pb.PolicyRequest{
 		EnforcerHandler: e.handler,
 		PType:           "g",
-		Params:          paramsToStrSlice(params),
+		Params:          rule,
 	})
 	if err != nil {
 		return false, err
@@ -171,11 +199,21 @@ func (e *Enforcer) AddGroupingPolicy(ctx context.Context, params ...interface{})
 // AddNamedGroupingPolicy adds a named role inheritance rule to the current policy.
 // If the rule already exists, the function returns false and the rule will not be added.
 // Otherwise the function returns true by adding the new rule.
+// For ptype "g", it fails with a *ConstraintError if the rule would break a constraint registered with AddConstraint.
 func (e *Enforcer) AddNamedGroupingPolicy(ctx context.Context, ptype string, params ...interface{}) (bool, error) {
+	rule, err := paramsToStrSlice(params)
+	if err != nil {
+		return false, err
+	}
+	if ptype == "g" {
+		if err := e.checkConstraints(ctx, rule); err != nil {
+			return false, err
+		}
+	}
 	res, err := e.client.remoteClient.AddNamedGroupingPolicy(ctx, &pb.PolicyRequest{
 		EnforcerHandler: e.handler,
 		PType:           ptype,
-		Params:          paramsToStrSlice(params),
+		Params:          rule,
 	})
 	if err != nil {
 		return false, err
@@ -185,10 +223,14 @@ func (e *Enforcer) AddNamedGroupingPolicy(ctx context.Context, ptype string, par
 
 // RemoveGroupingPolicy removes a role inheritance rule from the current policy.
 func (e *Enforcer) RemoveGroupingPolicy(ctx context.Context, params ...interface{}) (bool, error) {
+	rule, err := paramsToStrSlice(params)
+	if err != nil {
+		return false, err
+	}
 	res, err := e.client.remoteClient.RemoveGroupingPolicy(ctx, &pb.PolicyRequest{
 		EnforcerHandler: e.handler,
 		PType:           "g",
-		Params:          paramsToStrSlice(params),
+		Params:          rule,
 	})
 	if err != nil {
 		return false, err
@@ -198,10 +240,14 @@ func (e *Enforcer) RemoveGroupingPolicy(ctx context.Context, params ...interface
 
 // RemoveNamedGroupingPolicy removes a role inheritance rule from the current named policy.
 func (e *Enforcer) RemoveNamedGroupingPolicy(ctx context.Context, ptype string, params ...interface{}) (bool, error) {
+	rule, err := paramsToStrSlice(params)
+	if err != nil {
+		return false, err
+	}
 	res, err := e.client.remoteClient.RemoveNamedGroupingPolicy(ctx, &pb.PolicyRequest{
 		EnforcerHandler: e.handler,
 		PType:           ptype,
-		Params:          paramsToStrSlice(params),
+		Params:          rule,
 	})
 	if err != nil {
 		return false, err
@@ -373,10 +419,14 @@ func (e *Enforcer) GetAllNamedRoles(ctx context.Context, ptype string) ([]string
 
 // HasPolicy determines whether an authorization rule exists.
 func (e *Enforcer) HasPolicy(ctx context.Context, params ...interface{}) (bool, error) {
+	rule, err := paramsToStrSlice(params)
+	if err != nil {
+		return false, err
+	}
 	res, err := e.client.remoteClient.HasPolicy(ctx, &pb.PolicyRequest{
 		EnforcerHandler: e.handler,
 		PType:           "p",
-		Params:          paramsToStrSlice(params),
+		Params:          rule,
 	})
 	if err != nil {
 		return false, err
@@ -386,10 +436,14 @@ func (e *Enforcer) HasPolicy(ctx context.Context, params ...interface{}) (bool,
 
 // HasNamedPolicy determines whether a named authorization rule exists.
 func (e *Enforcer) HasNamedPolicy(ctx context.Context, ptype string, params ...interface{}) (bool, error) {
+	rule, err := paramsToStrSlice(params)
+	if err != nil {
+		return false, err
+	}
 	res, err := e.client.remoteClient.HasNamedPolicy(ctx, &pb.PolicyRequest{
 		EnforcerHandler: e.handler,
 		PType:           ptype,
-		Params:          paramsToStrSlice(params),
+		Params:          rule,
 	})
 	if err != nil {
 		return false, err
@@ -399,10 +453,14 @@ func (e *Enforcer) HasNamedPolicy(ctx context.Context, ptype string, params ...i
 
 // HasGroupingPolicy determines whether a role inheritance rule exists.
 func (e *Enforcer) HasGroupingPolicy(ctx context.Context, params ...interface{}) (bool, error) {
+	rule, err := paramsToStrSlice(params)
+	if err != nil {
+		return false, err
+	}
 	res, err := e.client.remoteClient.HasGroupingPolicy(ctx, &pb.PolicyRequest{
 		EnforcerHandler: e.handler,
 		PType:           "g",
-		Params:          paramsToStrSlice(params),
+		Params:          rule,
 	})
 	if err != nil {
 		return false, err
@@ -412,10 +470,14 @@ func (e *Enforcer) HasGroupingPolicy(ctx context.Context, params ...interface{})
 
 // HasNamedGroupingPolicy determines whether a named role inheritance rule exists.
 func (e *Enforcer) HasNamedGroupingPolicy(ctx context.Context, ptype string, params ...interface{}) (bool, error) {
+	rule, err := paramsToStrSlice(params)
+	if err != nil {
+		return false, err
+	}
 	res, err := e.client.remoteClient.HasNamedGroupingPolicy(ctx, &pb.PolicyRequest{
 		EnforcerHandler: e.handler,
 		PType:           ptype,
-		Params:          paramsToStrSlice(params),
+		Params:          rule,
 	})
 	if err != nil {
 		return false, err
@@ -423,18 +485,86 @@ func (e *Enforcer) HasNamedGroupingPolicy(ctx context.Context, ptype string, par
 	return res.Res, nil
 }
 
-// paramsToStrSlice transforms params, which can either be one string slice or several seperate
-// strings, into a slice of strings.
-func paramsToStrSlice(params []interface{}) []string {
-	if slice, ok := params[0].([]string); len(params) == 1 && ok {
-		return slice
+// ErrInvalidParams is the error wrapped by every *ParamError.
+var ErrInvalidParams = errors.New("invalid policy params")
+
+// ParamError is returned by the policy APIs when their params cannot be converted to a rule.
+type ParamError struct {
+	// Index is the position of the offending param, or -1 if the params as a whole are invalid.
+	Index int
+	// Value is the offending param.
+	Value interface{}
+	// Reason describes what is wrong with the params.
+	Reason string
+}
+
+func (e *ParamError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("%v: %s", ErrInvalidParams, e.Reason)
 	}
+	return fmt.Sprintf("%v: param %d (%T): %s", ErrInvalidParams, e.Index, e.Value, e.Reason)
+}
+
+func (e *ParamError) Unwrap() error {
+	return ErrInvalidParams
+}
 
-	slice := make([]string, 0)
-	for _, param := range params {
-		slice = append(slice, param.(string))
+// paramsToStrSlice transforms params, which can either be one string slice or several seperate
+// values, into a slice of strings.
+// Each value may be a string, a fmt.Stringer, a boolean or a number; numbers are formatted in base 10,
+// floats in their shortest exact representation (e.g. 0.5, 1e+21).
+func paramsToStrSlice(params []interface{}) ([]string, error) {
+	if len(params) == 0 {
+		return nil, &ParamError{Index: -1, Reason: "no params given"}
 	}
-	return slice
+	if slice, ok := params[0].([]string); len(params) == 1 && ok {
+		if len(slice) == 0 {
+			return nil, &ParamError{Index: 0, Value: slice, Reason: "empty string slice"}
+		}
+		return slice, nil
+	}
+
+	slice := make([]string, 0, len(params))
+	for i, param := range params {
+		value, err := paramToString(param)
+		if err != nil {
+			return nil, &ParamError{Index: i, Value: param, Reason: err.Error()}
+		}
+		slice = append(slice, value)
+	}
+	return slice, nil
+}
+
+// paramToString formats a single param of a policy rule.
+func paramToString(param interface{}) (string, error) {
+	switch v := param.(type) {
+	case nil:
+		return "", errors.New("nil value")
+	case string:
+		return v, nil
+	case fmt.Stringer:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return "", errors.New("nil value")
+		}
+		return v.String(), nil
+	}
+
+	rv := reflect.ValueOf(param)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64), nil
+	}
+	return "", errors.New("unsupported type")
 }
 
 // replyTo2DSlice transforms a Array2DReply to a 2d string slice.
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/casbin/casbin/v2/util"
+)
+
+type level string
+
+func TestParamsToStrSlice(t *testing.T) {
+	tests := []struct {
+		params []interface{}
+		res    []string
+	}{
+		{[]interface{}{"alice", "data1", "read"}, []string{"alice", "data1", "read"}},
+		{[]interface{}{[]string{"alice", "data1", "read"}}, []string{"alice", "data1", "read"}},
+		{[]interface{}{"alice", 42, int64(-7), uint8(3)}, []string{"alice", "42", "-7", "3"}},
+		{[]interface{}{true, 0.5, float32(1.25), 1e21}, []string{"true", "0.5", "1.25", "1e+21"}},
+		{[]interface{}{time.Second, level("high")}, []string{"1s", "high"}},
+	}
+	for _, test := range tests {
+		res, err := paramsToStrSlice(test.params)
+		if err != nil {
+			t.Errorf("paramsToStrSlice(%v) err: %v", test.params, err)
+			continue
+		}
+		if !util.ArrayEquals(res, test.res) {
+			t.Errorf("paramsToStrSlice(%v) = %v, supposed to be %v", test.params, res, test.res)
+		}
+	}
+}
+
+func TestParamsToStrSliceInvalid(t *testing.T) {
+	var nilDuration *time.Duration
+	tests := []struct {
+		params []interface{}
+		index  int
+	}{
+		{nil, -1},
+		{[]interface{}{[]string{}}, 0},
+		{[]interface{}{"alice", nil}, 1},
+		{[]interface{}{"alice", []string{"data1"}}, 1},
+		{[]interface{}{"alice", "data1", struct{}{}}, 2},
+		{[]interface{}{nilDuration}, 0},
+	}
+	for _, test := range tests {
+		_, err := paramsToStrSlice(test.params)
+		var paramErr *ParamError
+		if !errors.As(err, &paramErr) || !errors.Is(err, ErrInvalidParams) {
+			t.Errorf("paramsToStrSlice(%v) err: %v, supposed to be a *ParamError", test.params, err)
+			continue
+		}
+		if paramErr.Index != test.index {
+			t.Errorf("paramsToStrSlice(%v) error index: %d, supposed to be %d", test.params, paramErr.Index, test.index)
+		}
+	}
+}
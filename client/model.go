@@ -0,0 +1,297 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/casbin/casbin/v2/constant"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/util"
+	"github.com/casbin/govaluate"
+)
+
+// ErrInvalidModel is the error wrapped by ModelError.
+var ErrInvalidModel = errors.New("invalid model")
+
+// ModelError is a problem found in a model text by ValidateModel.
+type ModelError struct {
+	// Line is the line of the problem in the model text, starting at 1,
+	// or 0 if the problem is not tied to a line, e.g. a missing section.
+	Line int
+	// Key is the definition involved, e.g. "m", if any.
+	Key     string
+	Message string
+}
+
+func (e *ModelError) Error() string {
+	var b strings.Builder
+	b.WriteString(ErrInvalidModel.Error())
+	if e.Line > 0 {
+		fmt.Fprintf(&b, ": line %d", e.Line)
+	}
+	if e.Key != "" {
+		fmt.Fprintf(&b, ": %s", e.Key)
+	}
+	fmt.Fprintf(&b, ": %s", e.Message)
+	return b.String()
+}
+
+func (e *ModelError) Unwrap() error {
+	return ErrInvalidModel
+}
+
+// modelSections maps the section names of a model text to the keys of their definitions.
+var modelSections = map[string]string{
+	"request_definition": "r",
+	"policy_definition":  "p",
+	"role_definition":    "g",
+	"policy_effect":      "e",
+	"matchers":           "m",
+}
+
+// modelDefinition is a definition of a model text, e.g. "m = r.sub == p.sub" of the matchers section.
+type modelDefinition struct {
+	line  int
+	sec   string
+	key   string
+	value string
+}
+
+// definitionKeyPattern matches the keys casbin loads: "p", "p2", "p3"...
+var definitionKeyPattern = regexp.MustCompile(`^([a-z])([2-9]|[1-9][0-9]+)?$`)
+
+// supportedEffects are the policy effects casbin implements, without spaces.
+var supportedEffects = []string{
+	constant.AllowOverrideEffect,
+	constant.DenyOverrideEffect,
+	constant.AllowAndDenyEffect,
+	constant.PriorityEffect,
+	constant.SubjectPriorityEffect,
+}
+
+// ValidateModel checks a model text the way casbin loads it, and returns a *ModelError for the first problem found:
+//   - lines outside of a section or without "=", and unknown sections,
+//   - definitions casbin ignores, e.g. "p1" or "p3" without "p2", or defined twice,
+//   - missing request_definition, policy_definition, policy_effect or matchers sections,
+//   - effects casbin does not implement,
+//   - matchers that do not compile, call unknown functions or use unknown request and policy fields.
+//
+// Client.NewEnforcer calls it before sending the model to the server.
+func ValidateModel(text string) error {
+	definitions, err := scanModel(text)
+	if err != nil {
+		return err
+	}
+
+	lines := make(map[string]int)
+	for _, def := range definitions {
+		lines[def.key] = def.line
+	}
+	for _, sec := range []string{"r", "p", "e", "m"} {
+		if _, ok := lines[sec]; !ok {
+			return &ModelError{Key: sec, Message: fmt.Sprintf("missing definition in section [%s]", sectionName(sec))}
+		}
+	}
+	for _, def := range definitions {
+		if prev := previousKey(def.sec, def.key); prev != "" && lines[prev] == 0 {
+			return &ModelError{Line: def.line, Key: def.key, Message: fmt.Sprintf("ignored by casbin without %s", prev)}
+		}
+	}
+
+	m, err := model.NewModelFromString(text)
+	if err != nil {
+		return &ModelError{Message: err.Error()}
+	}
+	for _, def := range definitions {
+		if err := validateDefinition(m, def); err != nil {
+			return &ModelError{Line: def.line, Key: def.key, Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+// scanModel returns the definitions of a model text, parsed like casbin's config package does:
+// "#" and ";" start comments, and a line ending with "\" continues on the next line.
+func scanModel(text string) ([]modelDefinition, error) {
+	var definitions []modelDefinition
+	seen := make(map[string]bool)
+	section := ""
+	var pending *modelDefinition
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if pending == nil && (line == "" || line[0] == '#' || line[0] == ';') {
+			continue
+		}
+		if pending == nil && strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = line[1 : len(line)-1]
+			if _, ok := modelSections[section]; !ok {
+				return nil, &ModelError{Line: lineNum, Message: fmt.Sprintf("unknown section [%s]", section)}
+			}
+			continue
+		}
+
+		continued := strings.HasSuffix(line, `\`)
+		line = strings.TrimSuffix(line, `\`)
+		if i := strings.IndexAny(line, "#;"); i >= 0 {
+			line = line[:i]
+		}
+		if pending != nil {
+			pending.value += " " + strings.TrimSpace(line)
+		} else {
+			if section == "" {
+				return nil, &ModelError{Line: lineNum, Message: "definition outside of a section"}
+			}
+			pending = &modelDefinition{line: lineNum, value: line}
+		}
+		if continued {
+			continue
+		}
+
+		def := *pending
+		pending = nil
+		key, value, ok := strings.Cut(def.value, "=")
+		if !ok {
+			return nil, &ModelError{Line: def.line, Message: fmt.Sprintf("missing \"=\" in %q", strings.TrimSpace(def.value))}
+		}
+		def.sec = modelSections[section]
+		def.key = strings.TrimSpace(key)
+		def.value = strings.TrimSpace(value)
+		if match := definitionKeyPattern.FindStringSubmatch(def.key); match == nil || match[1] != def.sec {
+			return nil, &ModelError{Line: def.line, Key: def.key,
+				Message: fmt.Sprintf("ignored by casbin, the definitions of [%s] are named %s, %s2, %s3...", section, def.sec, def.sec, def.sec)}
+		}
+		if seen[def.key] {
+			return nil, &ModelError{Line: def.line, Key: def.key, Message: "defined twice"}
+		}
+		if def.value == "" {
+			return nil, &ModelError{Line: def.line, Key: def.key, Message: "empty definition"}
+		}
+		seen[def.key] = true
+		definitions = append(definitions, def)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
+
+func sectionName(sec string) string {
+	for name, s := range modelSections {
+		if s == sec {
+			return name
+		}
+	}
+	return sec
+}
+
+// previousKey returns the definition casbin needs to load key, e.g. "p2" for "p3", or "" for "p".
+func previousKey(sec, key string) string {
+	suffix := strings.TrimPrefix(key, sec)
+	if suffix == "" {
+		return ""
+	}
+	var n int
+	if _, err := fmt.Sscan(suffix, &n); err != nil || n <= 2 {
+		return sec
+	}
+	return fmt.Sprintf("%s%d", sec, n-1)
+}
+
+// validateDefinition checks the definition once loaded by casbin.
+func validateDefinition(m model.Model, def modelDefinition) error {
+	ast := m[def.sec][def.key]
+	switch def.sec {
+	case "r", "p":
+		for _, token := range ast.Tokens {
+			if field := strings.TrimPrefix(token, def.key+"_"); field == "" || strings.ContainsAny(field, " .()") {
+				return fmt.Errorf("invalid field %q", field)
+			}
+		}
+	case "g":
+		if len(ast.Tokens) < 2 {
+			return fmt.Errorf("%q has less than 2 fields", def.value)
+		}
+	case "e":
+		compact := strings.ReplaceAll(ast.Value, " ", "")
+		for _, effect := range supportedEffects {
+			if compact == strings.ReplaceAll(effect, " ", "") {
+				return nil
+			}
+		}
+		return fmt.Errorf("unsupported effect %q, casbin implements %s", def.value, strings.ReplaceAll(strings.Join(supportedEffects, ", "), "_", "."))
+	case "m":
+		return validateMatcher(m, ast.Value)
+	}
+	return nil
+}
+
+// fieldPattern matches the request and policy fields of an escaped matcher, e.g. "r_sub" or "p2_obj".
+var fieldPattern = regexp.MustCompile(`^([rp][0-9]*)_`)
+
+// validateMatcher compiles an escaped matcher with the functions of casbin and the role definitions of m,
+// and checks that the request and policy fields it uses are defined.
+func validateMatcher(m model.Model, matcher string) error {
+	fm := model.LoadFunctionMap()
+	functions := fm.GetFunctions()
+	stub := func(args ...interface{}) (interface{}, error) { return false, nil }
+	for key := range m["g"] {
+		functions[key] = stub
+	}
+	if util.HasEval(matcher) {
+		functions["eval"] = stub
+	}
+	expression, err := govaluate.NewEvaluableExpressionWithFunctions(matcher, functions)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range expression.Tokens() {
+		var name string
+		switch value := token.Value.(type) {
+		case string:
+			if token.Kind != govaluate.VARIABLE {
+				continue
+			}
+			name = value
+		case []string:
+			if token.Kind != govaluate.ACCESSOR || len(value) == 0 {
+				continue
+			}
+			name = value[0]
+		default:
+			continue
+		}
+
+		match := fieldPattern.FindStringSubmatch(name)
+		if match == nil {
+			return fmt.Errorf("unknown variable %q", name)
+		}
+		ast, ok := m[match[1][:1]][match[1]]
+		if !ok {
+			return fmt.Errorf("%q uses the undefined %s", strings.Replace(name, "_", ".", 1), match[1])
+		}
+		if !containsString(ast.Tokens, name) {
+			return fmt.Errorf("%q is not a field of %s = %s", strings.Replace(name, "_", ".", 1), match[1], ast.Value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func TestValidateModel(t *testing.T) {
+	example, err := os.ReadFile("../examples/rbac_model.conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, text := range []string{string(example), rbacModelText, rbacWithDomainsModelText, rbacWithDenyModelText, rbacWithNamedTypesModelText} {
+		if err := ValidateModel(text); err != nil {
+			t.Errorf("ValidateModel err: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		replace [2]string
+		line    int
+		message string
+	}{
+		{"unknown section", [2]string{"[matchers]", "[matcher]"}, 14, "unknown section"},
+		{"missing =", [2]string{"g = _, _", "g _, _"}, 9, `missing "="`},
+		{"ignored key", [2]string{"p = sub", "p1 = sub"}, 6, "ignored by casbin"},
+		{"missing section", [2]string{"r = sub, obj, act", ""}, 0, "missing definition in section [request_definition]"},
+		{"effect", [2]string{"p.eft == allow", "p.eft == permit"}, 12, "unsupported effect"},
+		{"matcher syntax", [2]string{"r.act == p.act", "r.act == == p.act"}, 15, ""},
+		{"unknown function", [2]string{"g(r.sub", "g3(r.sub"}, 15, "g3"},
+		{"unknown field", [2]string{"r.act == p.act", "r.action == p.act"}, 15, `"r.action" is not a field of r`},
+	}
+	for _, test := range tests {
+		text := strings.Replace(rbacModelText, test.replace[0], test.replace[1], 1)
+		err := ValidateModel(text)
+		var modelErr *ModelError
+		if !errors.As(err, &modelErr) || !errors.Is(err, ErrInvalidModel) {
+			t.Errorf("%s: ValidateModel err = %v, supposed to be a *ModelError", test.name, err)
+			continue
+		}
+		if modelErr.Line != test.line || !strings.Contains(modelErr.Message, test.message) {
+			t.Errorf("%s: ValidateModel err = %v, supposed to be at line %d with %q", test.name, err, test.line, test.message)
+		}
+	}
+}
+
+func TestNewEnforcerInvalidModel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	// Nothing listens there: the model must be rejected before any request.
+	cc, err := NewClient(ctx, "localhost:1", grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("connot create client: %v", err)
+	}
+	_, err = cc.NewEnforcer(ctx, Config{ModelText: strings.Replace(rbacModelText, "[matchers]", "[matcher]", 1)})
+	if !errors.Is(err, ErrInvalidModel) {
+		t.Errorf("NewEnforcer err = %v, supposed to be ErrInvalidModel", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import "context"
+
+// OffboardOptions controls which rules Enforcer.Offboard removes.
+type OffboardOptions struct {
+	// IncludeObjects also removes the authorization rules whose object is the user.
+	IncludeObjects bool
+	// DryRun lists the rules to remove without sending anything to the server.
+	DryRun bool
+	// PTypes are the ptypes to search, e.g. {"p", "p2", "g", "g2"}.
+	// By default, the ptypes of Config.ModelText.
+	PTypes []string
+}
+
+// OffboardReport lists the rules removed, or to remove in dry-run mode, by Enforcer.Offboard.
+type OffboardReport struct {
+	User string `json:"user"`
+	// Removed lists the rules in the order of the ptypes of the model.
+	Removed []Rule `json:"removed"`
+	// DryRun is set when nothing was sent to the server.
+	DryRun bool `json:"dryRun"`
+}
+
+// Offboard removes every rule mentioning user, in every ptype of the model and every domain:
+// the role inheritance rules where it is the user or the role, and the authorization rules where it is
+// the subject or, with opts.IncludeObjects, the object.
+// Unlike DeleteUser, it also covers named ptypes such as "p2" and "g2", which are only known with Config.ModelText:
+// without it, Offboard returns ErrUnknownModel unless opts.PTypes lists the ptypes, so that no rule is left behind.
+// The removals are applied with Tx, so a failure reverts the removals made so far.
+func (e *Enforcer) Offboard(ctx context.Context, user string, opts OffboardOptions) (*OffboardReport, error) {
+	ptypes := opts.PTypes
+	if len(ptypes) == 0 {
+		if e.model == nil {
+			return nil, ErrUnknownModel
+		}
+		ptypes = e.ptypes()
+	}
+	rules, err := e.getRules(ctx, ptypes)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OffboardReport{User: user, Removed: []Rule{}, DryRun: opts.DryRun}
+	for _, rule := range rules {
+		if mentions(rule, user, opts.IncludeObjects) {
+			report.Removed = append(report.Removed, rule)
+		}
+	}
+	if opts.DryRun || len(report.Removed) == 0 {
+		return report, nil
+	}
+
+	_, err = e.Tx(ctx, func(tx *PolicyTx) error {
+		for _, rule := range report.Removed {
+			tx.record(TxRemove, rule.PType, rule.Values)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// mentions reports whether user is the user or role of a role inheritance rule,
+// or the subject, or with objects the object, of an authorization rule.
+func mentions(rule Rule, user string, objects bool) bool {
+	if rule.IsGrouping() {
+		return rule.User() == user || rule.Role() == user
+	}
+	if sub, ok := rule.Field("sub"); ok {
+		if sub == user {
+			return true
+		}
+	} else if len(rule.Values) > 0 && rule.Values[0] == user {
+		return true
+	}
+	return objects && rule.Obj() == user
+}
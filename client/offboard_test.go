@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+const rbacWithNamedTypesModelText = `
+[request_definition]
+r = sub, obj, act
+r2 = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+p2 = sub, obj, act
+
+[role_definition]
+g = _, _
+g2 = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
+m2 = g2(r2.sub, p2.sub) && r2.obj == p2.obj && r2.act == p2.act
+`
+
+func TestOffboard(t *testing.T) {
+	e := newTestEnforcer(t, rbacWithNamedTypesModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("p", "alice", "data1", "read"),
+		NewRule("p", "bob", "alice", "read"),
+		NewRule("p2", "alice", "data2", "write"),
+		NewRule("g", "alice", "admin"),
+		NewRule("g2", "alice", "ops"),
+		NewRule("g2", "bob", "ops"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+
+	report, err := e.Offboard(ctx, "alice", OffboardOptions{IncludeObjects: true, DryRun: true})
+	if err != nil || len(report.Removed) != 5 {
+		t.Fatalf("Offboard dry run = %v, %v", report, err)
+	}
+	if rules, _ := e.getRules(ctx, e.ptypes()); len(rules) != 6 {
+		t.Fatalf("the dry run changed the policy: %v", rules)
+	}
+
+	report, err = e.Offboard(ctx, "alice", OffboardOptions{})
+	if err != nil || len(report.Removed) != 4 {
+		t.Fatalf("Offboard = %v, %v", report, err)
+	}
+	rules, err := e.getRules(ctx, e.ptypes())
+	if err != nil {
+		t.Fatalf("getRules err: %v", err)
+	}
+	if len(rules) != 2 || rules[0].String() != "p, bob, alice, read" || rules[1].String() != "g2, bob, ops" {
+		t.Errorf("rules left: %v", rules)
+	}
+}
+
+func TestOffboardUnknownModel(t *testing.T) {
+	named := newTestEnforcer(t, rbacWithNamedTypesModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := named.AddRules(ctx, []Rule{
+		NewRule("p", "alice", "data1", "read"),
+		NewRule("p2", "alice", "data2", "write"),
+		NewRule("g2", "alice", "ops"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+	// The same enforcer, as seen by a client created without Config.ModelText.
+	e := &Enforcer{client: named.client, handler: named.handler}
+
+	if _, err := e.Offboard(ctx, "alice", OffboardOptions{}); !errors.Is(err, ErrUnknownModel) {
+		t.Fatalf("Offboard err = %v, supposed to be ErrUnknownModel", err)
+	}
+	report, err := e.Offboard(ctx, "alice", OffboardOptions{PTypes: []string{"p", "p2", "g", "g2"}})
+	if err != nil || len(report.Removed) != 3 {
+		t.Fatalf("Offboard = %v, %v", report, err)
+	}
+	if rules, err := named.getRules(ctx, named.ptypes()); err != nil || len(rules) != 0 {
+		t.Errorf("rules left: %v, %v", rules, err)
+	}
+}
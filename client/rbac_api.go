@@ -18,6 +18,7 @@ import (
 	"context"
 
 	pb "github.com/casbin/casbin-server/proto"
+	"github.com/casbin/casbin/v2/util"
 )
 
 // GetRolesForUser gets the roles that a user has.
@@ -40,7 +41,18 @@ func (e *Enforcer) GetRolesForUser(ctx context.Context, name string) ([]string,
 //
 // GetRolesForUser("alice") can only get: ["role:admin"].
 // But GetImplicitRolesForUser("alice") will get: ["role:admin", "role:user"].
+//
+// casbin-server ignores the domain of this call, so with a domain the role inheritance rules
+// are fetched once and walked by the client, following only the rules of that domain.
 func (e *Enforcer) GetImplicitRolesForUser(ctx context.Context, name string, domain ...string) ([]string, error) {
+	if len(domain) > 0 && domain[0] != "" {
+		rules, err := e.GetGroupingPolicyRules(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return newRoleIndex(rules).roles(name, domain[0], true), nil
+	}
+
 	res, err := e.client.remoteClient.GetImplicitRolesForUser(ctx, &pb.UserRoleRequest{
 		EnforcerHandler: e.handler,
 		User:            name,
@@ -53,9 +65,11 @@ func (e *Enforcer) GetImplicitRolesForUser(ctx context.Context, name string, dom
 
 // GetUsersForRole gets the users that has a role.
 func (e *Enforcer) GetUsersForRole(ctx context.Context, name string) ([]string, error) {
+	// casbin-server reads the role of the request.
 	res, err := e.client.remoteClient.GetUsersForRole(ctx, &pb.UserRoleRequest{
 		EnforcerHandler: e.handler,
 		User:            name,
+		Role:            name,
 	})
 	if err != nil {
 		return nil, err
@@ -78,7 +92,11 @@ func (e *Enforcer) HasRoleForUser(ctx context.Context, user, role string) (bool,
 
 // AddRoleForUser adds a role for a user.
 // Returns false if the user already has the role (aka not affected).
+// It fails with a *ConstraintError if the rule would break a constraint registered with AddConstraint.
 func (e *Enforcer) AddRoleForUser(ctx context.Context, user, role string) (bool, error) {
+	if err := e.checkConstraints(ctx, []string{user, role}); err != nil {
+		return false, err
+	}
 	res, err := e.client.remoteClient.AddRoleForUser(ctx, &pb.UserRoleRequest{
 		EnforcerHandler: e.handler,
 		User:            user,
@@ -164,6 +182,7 @@ func (e *Enforcer) GetImplicitPermissionsForUser(ctx context.Context, user strin
 	res, err := e.client.remoteClient.GetImplicitPermissionsForUser(ctx, &pb.PermissionRequest{
 		EnforcerHandler: e.handler,
 		User:            user,
+		Domain:          domain,
 	})
 	if err != nil {
 		return nil, err
@@ -237,3 +256,166 @@ func (e *Enforcer) HasPermissionForUser(ctx context.Context, user string, permis
 	}
 	return res.Res, nil
 }
+
+// GetImplicitUsersForRole gets implicit users for a role.
+// Compared to GetUsersForRole(), this function retrieves indirect users besides direct users.
+// For example:
+// g, alice, role:admin
+// g, role:admin, role:user
+//
+// GetUsersForRole("role:user") can only get: ["role:admin"].
+// But GetImplicitUsersForRole("role:user") will get: ["alice", "role:admin"].
+//
+// casbin-server has no such query, so the role inheritance rules are fetched once and walked by the client.
+func (e *Enforcer) GetImplicitUsersForRole(ctx context.Context, name string, domain ...string) ([]string, error) {
+	indexes, err := e.roleIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return implicitUsers(indexes, name, domain...), nil
+}
+
+// GetImplicitUsersForPermission gets implicit users for a permission.
+// For example:
+// p, admin, data1, read
+// p, bob, data1, read
+// g, alice, admin
+//
+// GetImplicitUsersForPermission("data1", "read") will get: ["alice", "bob"].
+// Note: only users will be returned, roles (2nd arg in "g") will be excluded.
+//
+// The candidate users are evaluated against a local copy of the policy when Config.ModelText is set,
+// and with one Enforce call each otherwise.
+func (e *Enforcer) GetImplicitUsersForPermission(ctx context.Context, permission ...string) ([]string, error) {
+	ptypes := e.ptypes()
+	rules, err := e.getRules(ctx, ptypes)
+	if err != nil {
+		return nil, err
+	}
+
+	subIndex, ok := e.fieldIndex("p", "sub")
+	if !ok {
+		subIndex = -1
+	}
+	seen := make(map[string]bool)
+	roles := make(map[string]bool)
+	var subjects []string
+	for _, rule := range rules {
+		// The candidates are the subjects of "p" rules and the users of role inheritance rules.
+		index := subIndex
+		if rule.IsGrouping() {
+			index = 0
+			if len(rule.Values) > 1 {
+				roles[rule.Values[1]] = true
+			}
+		} else if rule.PType != "p" {
+			continue
+		}
+		if index < 0 || index >= len(rule.Values) {
+			continue
+		}
+		if subject := rule.Values[index]; !seen[subject] {
+			seen[subject] = true
+			subjects = append(subjects, subject)
+		}
+	}
+
+	enforce := func(rvals ...interface{}) (bool, error) { return e.Enforce(ctx, rvals...) }
+	if e.modelText != "" {
+		local, err := e.localEnforcer(rules)
+		if err != nil {
+			return nil, err
+		}
+		enforce = local.Enforce
+	}
+
+	res := []string{}
+	for _, user := range subjects {
+		if roles[user] {
+			continue
+		}
+		allowed, err := enforce(util.JoinSliceAny(user, permission...)...)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			res = append(res, user)
+		}
+	}
+	return res, nil
+}
+
+// GetImplicitResourcesForUser returns all policies that user obtaining in domain.
+// The fields of the inherited rules are expanded into the users they contain, e.g. with
+// p, book_admin, book_group, read
+// g, alice, book_admin
+// g2, book1, book_group
+//
+// GetImplicitResourcesForUser("alice") will get: [["alice", "book1", "read"], ["alice", "book_group", "read"]].
+func (e *Enforcer) GetImplicitResourcesForUser(ctx context.Context, user string, domain ...string) ([][]string, error) {
+	permissions, err := e.GetImplicitPermissionsForUser(ctx, user, domain...)
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := e.roleIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([][]string, 0, len(permissions))
+	for _, permission := range permissions {
+		if permission[0] == user {
+			res = append(res, permission)
+			continue
+		}
+		expanded := [][]string{{user}}
+		for _, token := range permission[1:] {
+			values := append(implicitUsers(indexes, token, domain...), token)
+			next := make([][]string, 0, len(expanded)*len(values))
+			for _, value := range values {
+				for _, prefix := range expanded {
+					rule := append(append(make([]string, 0, len(permission)), prefix...), value)
+					next = append(next, rule)
+				}
+			}
+			expanded = next
+		}
+		res = append(res, expanded...)
+	}
+	return res, nil
+}
+
+// roleIndexes fetches the rules of every role inheritance ptype of the model, and indexes each ptype.
+func (e *Enforcer) roleIndexes(ctx context.Context) ([]*roleIndex, error) {
+	var indexes []*roleIndex
+	for _, ptype := range e.ptypes() {
+		if !isGroupingPType(ptype) {
+			continue
+		}
+		rules, err := e.GetNamedGroupingPolicyRules(ctx, ptype)
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, newRoleIndex(rules))
+	}
+	return indexes, nil
+}
+
+// implicitUsers returns the direct and indirect users of role in any of the indexes.
+func implicitUsers(indexes []*roleIndex, role string, domain ...string) []string {
+	dom := ""
+	if len(domain) > 0 {
+		dom = domain[0]
+	}
+	seen := make(map[string]bool)
+	users := []string{}
+	for _, ri := range indexes {
+		for _, user := range ri.users(role, dom, true) {
+			if !seen[user] {
+				seen[user] = true
+				users = append(users, user)
+			}
+		}
+	}
+	return users
+}
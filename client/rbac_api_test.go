@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+)
+
+const rbacWithResourceRolesModelText = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+g2 = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && g2(r.obj, p.obj) && r.act == p.act
+`
+
+func TestImplicitUsers(t *testing.T) {
+	e := newTestEnforcer(t, rbacWithResourceRolesModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("p", "bob", "data1", "read"),
+		NewRule("p", "data_admin", "data_group", "read"),
+		NewRule("g", "alice", "data_admin"),
+		NewRule("g", "data_admin", "data_reader"),
+		NewRule("g2", "data1", "data_group"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+
+	users, err := e.GetImplicitUsersForRole(ctx, "data_reader")
+	if err != nil || !stringsEqual(users, "alice", "data_admin") {
+		t.Errorf("GetImplicitUsersForRole = %v, %v", users, err)
+	}
+	users, err = e.GetImplicitUsersForPermission(ctx, "data1", "read")
+	if err != nil || !stringsEqual(users, "alice", "bob") {
+		t.Errorf("GetImplicitUsersForPermission = %v, %v", users, err)
+	}
+
+	resources, err := e.GetImplicitResourcesForUser(ctx, "alice")
+	if err != nil {
+		t.Fatalf("GetImplicitResourcesForUser err: %v", err)
+	}
+	testGetPolicy(t, resources, [][]string{{"alice", "data1", "read"}, {"alice", "data_group", "read"}})
+}
+
+func TestImplicitUsersForPermissionSubjectIndex(t *testing.T) {
+	e := newTestEnforcer(t, objFirstModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("p", "data1", "alice", "read"),
+		NewRule("p", "data1", "admin", "read"),
+		NewRule("g", "bob", "admin"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+	users, err := e.GetImplicitUsersForPermission(ctx, "data1", "read")
+	if err != nil || !stringsEqual(users, "alice", "bob") {
+		t.Errorf("GetImplicitUsersForPermission = %v, %v", users, err)
+	}
+}
+
+func TestGetUsersForRole(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("g", "alice", "admin"),
+		NewRule("g", "bob", "admin"),
+		NewRule("g", "admin", "reader"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+	users, err := e.GetUsersForRole(ctx, "admin")
+	if err != nil || !stringsEqual(users, "alice", "bob") {
+		t.Errorf("GetUsersForRole = %v, %v", users, err)
+	}
+}
+
+func stringsEqual(got []string, want ...string) bool {
+	sorted := append([]string(nil), got...)
+	sort.Strings(sorted)
+	if len(sorted) != len(want) {
+		return false
+	}
+	for i := range want {
+		if sorted[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	pb "github.com/casbin/casbin-server/proto"
+)
+
+// domainIndex is the position of the domain in "g" rules "g, user, role, domain".
+const domainIndex = 2
+
+// GetUsersForRoleInDomain gets the users that has a role inside a domain.
+func (e *Enforcer) GetUsersForRoleInDomain(ctx context.Context, name string, domain string) ([]string, error) {
+	rules, err := e.GetFilteredGroupingPolicy(ctx, 1, name, domain)
+	if err != nil {
+		return nil, err
+	}
+	return uniqueField(rules, 0), nil
+}
+
+// GetRolesForUserInDomain gets the roles that a user has inside a domain.
+func (e *Enforcer) GetRolesForUserInDomain(ctx context.Context, name string, domain string) ([]string, error) {
+	res, err := e.client.remoteClient.GetRolesForUser(ctx, &pb.UserRoleRequest{
+		EnforcerHandler: e.handler,
+		User:            name,
+		Domain:          []string{domain},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Array, nil
+}
+
+// GetPermissionsForUserInDomain gets permissions for a user or role inside a domain,
+// including the permissions of the roles it inherits in that domain.
+func (e *Enforcer) GetPermissionsForUserInDomain(ctx context.Context, user string, domain string) ([][]string, error) {
+	return e.GetImplicitPermissionsForUser(ctx, user, domain)
+}
+
+// AddRoleForUserInDomain adds a role for a user inside a domain.
+// Returns false if the user already has the role (aka not affected).
+func (e *Enforcer) AddRoleForUserInDomain(ctx context.Context, user string, role string, domain string) (bool, error) {
+	return e.AddGroupingPolicy(ctx, user, role, domain)
+}
+
+// DeleteRoleForUserInDomain deletes a role for a user inside a domain.
+// Returns false if the user does not have the role (aka not affected).
+func (e *Enforcer) DeleteRoleForUserInDomain(ctx context.Context, user string, role string, domain string) (bool, error) {
+	return e.RemoveGroupingPolicy(ctx, user, role, domain)
+}
+
+// DeleteRolesForUserInDomain deletes all roles for a user inside a domain.
+// Returns false if the user does not have any roles (aka not affected).
+func (e *Enforcer) DeleteRolesForUserInDomain(ctx context.Context, user string, domain string) (bool, error) {
+	return e.RemoveFilteredGroupingPolicy(ctx, 0, user, "", domain)
+}
+
+// GetDomainsForUser gets the domains in which a user has roles.
+func (e *Enforcer) GetDomainsForUser(ctx context.Context, user string) ([]string, error) {
+	res, err := e.client.remoteClient.GetDomains(ctx, &pb.UserRoleRequest{
+		EnforcerHandler: e.handler,
+		User:            user,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Array, nil
+}
+
+// GetAllUsersByDomain gets all the users associated with the domain,
+// as the user of a role inheritance rule or the subject of an authorization rule in the domain.
+func (e *Enforcer) GetAllUsersByDomain(ctx context.Context, domain string) ([]string, error) {
+	index, err := e.policyDomainIndex()
+	if err != nil {
+		return nil, err
+	}
+	grouping, err := e.GetFilteredGroupingPolicy(ctx, domainIndex, domain)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := e.GetFilteredPolicy(ctx, int32(index), domain)
+	if err != nil {
+		return nil, err
+	}
+	return uniqueField(append(grouping, policy...), 0), nil
+}
+
+// DeleteAllUsersByDomain deletes all the rules of the domain, i.e. all the users and roles associated with it.
+// Returns false if the domain has no rules (aka not affected).
+func (e *Enforcer) DeleteAllUsersByDomain(ctx context.Context, domain string) (bool, error) {
+	index, err := e.policyDomainIndex()
+	if err != nil {
+		return false, err
+	}
+	removedGrouping, err := e.RemoveFilteredGroupingPolicy(ctx, domainIndex, domain)
+	if err != nil {
+		return false, err
+	}
+	removedPolicy, err := e.RemoveFilteredPolicy(ctx, int32(index), domain)
+	if err != nil {
+		return false, err
+	}
+	return removedGrouping || removedPolicy, nil
+}
+
+// DeleteDomains deletes all the rules of the domains.
+// If no domain is given, every domain returned by GetAllDomains is deleted.
+// Unlike casbin, rules outside any domain are kept, as the server offers no way to clear the policy.
+func (e *Enforcer) DeleteDomains(ctx context.Context, domains ...string) (bool, error) {
+	if len(domains) == 0 {
+		var err error
+		domains, err = e.GetAllDomains(ctx)
+		if err != nil {
+			return false, err
+		}
+	}
+	affected := false
+	for _, domain := range domains {
+		ok, err := e.DeleteAllUsersByDomain(ctx, domain)
+		if err != nil {
+			return affected, err
+		}
+		affected = affected || ok
+	}
+	return affected, nil
+}
+
+// GetAllDomains gets all the domains of the role inheritance rules, sorted.
+func (e *Enforcer) GetAllDomains(ctx context.Context) ([]string, error) {
+	rules, err := e.GetGroupingPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	domains := uniqueField(rules, domainIndex)
+	sort.Strings(domains)
+	return domains, nil
+}
+
+// GetAllRolesByDomain gets all the roles associated with the domain.
+// It does not include the roles inherited from other domains.
+func (e *Enforcer) GetAllRolesByDomain(ctx context.Context, domain string) ([]string, error) {
+	rules, err := e.GetFilteredGroupingPolicy(ctx, domainIndex, domain)
+	if err != nil {
+		return nil, err
+	}
+	return uniqueField(rules, 1), nil
+}
+
+// policyDomainIndex returns the position of the "dom" field in "p" rules.
+func (e *Enforcer) policyDomainIndex() (int, error) {
+	if e.model == nil {
+		return 0, ErrUnknownModel
+	}
+	index, ok := e.fieldIndex("p", "dom")
+	if !ok {
+		return 0, errors.New(`the policy_definition of the model has no "dom" field`)
+	}
+	return index, nil
+}
+
+// uniqueField returns the distinct values of field index of rules, in order of first appearance.
+func uniqueField(rules [][]string, index int) []string {
+	seen := make(map[string]bool, len(rules))
+	values := []string{}
+	for _, rule := range rules {
+		if index >= len(rule) || seen[rule[index]] {
+			continue
+		}
+		seen[rule[index]] = true
+		values = append(values, rule[index])
+	}
+	return values
+}
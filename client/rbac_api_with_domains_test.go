@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+const rbacWithDomainsModelText = `
+[request_definition]
+r = sub, dom, obj, act
+
+[policy_definition]
+p = sub, dom, obj, act
+
+[role_definition]
+g = _, _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub, r.dom) && r.dom == p.dom && r.obj == p.obj && r.act == p.act
+`
+
+func TestRBACWithDomains(t *testing.T) {
+	e := newTestEnforcer(t, rbacWithDomainsModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("p", "admin", "domain1", "data1", "read"),
+		NewRule("p", "reader", "domain1", "data1", "read"),
+		NewRule("p", "admin", "domain2", "data2", "read"),
+		NewRule("g", "admin", "reader", "domain1"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+	if ok, err := e.AddRoleForUserInDomain(ctx, "alice", "admin", "domain1"); !ok || err != nil {
+		t.Fatalf("AddRoleForUserInDomain = %v, %v", ok, err)
+	}
+	if ok, err := e.AddRoleForUserInDomain(ctx, "bob", "admin", "domain2"); !ok || err != nil {
+		t.Fatalf("AddRoleForUserInDomain = %v, %v", ok, err)
+	}
+
+	check := func(name string, got []string, err error, want ...string) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s err: %v", name, err)
+		}
+		sort.Strings(got)
+		if len(got)+len(want) > 0 && !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %v, supposed to be %v", name, got, want)
+		}
+	}
+	roles, err := e.GetRolesForUserInDomain(ctx, "alice", "domain1")
+	check("GetRolesForUserInDomain", roles, err, "admin")
+	roles, err = e.GetImplicitRolesForUser(ctx, "alice", "domain1")
+	check("GetImplicitRolesForUser", roles, err, "admin", "reader")
+	roles, err = e.GetImplicitRolesForUser(ctx, "alice", "domain2")
+	check("GetImplicitRolesForUser", roles, err)
+	users, err := e.GetUsersForRoleInDomain(ctx, "admin", "domain2")
+	check("GetUsersForRoleInDomain", users, err, "bob")
+	users, err = e.GetAllUsersByDomain(ctx, "domain1")
+	check("GetAllUsersByDomain", users, err, "admin", "alice", "reader")
+	domains, err := e.GetAllDomains(ctx)
+	check("GetAllDomains", domains, err, "domain1", "domain2")
+	domains, err = e.GetDomainsForUser(ctx, "alice")
+	check("GetDomainsForUser", domains, err, "domain1")
+	roles, err = e.GetAllRolesByDomain(ctx, "domain1")
+	check("GetAllRolesByDomain", roles, err, "admin", "reader")
+
+	permissions, err := e.GetPermissionsForUserInDomain(ctx, "bob", "domain2")
+	if err != nil {
+		t.Fatalf("GetPermissionsForUserInDomain err: %v", err)
+	}
+	testGetPolicy(t, permissions, [][]string{{"admin", "domain2", "data2", "read"}})
+
+	if ok, err := e.DeleteDomains(ctx, "domain1"); !ok || err != nil {
+		t.Fatalf("DeleteDomains = %v, %v", ok, err)
+	}
+	policies, err := e.GetPolicy(ctx)
+	if err != nil {
+		t.Fatalf("GetPolicy err: %v", err)
+	}
+	testGetPolicy(t, policies, [][]string{{"admin", "domain2", "data2", "read"}})
+	if ok, err := e.DeleteRoleForUserInDomain(ctx, "bob", "admin", "domain2"); !ok || err != nil {
+		t.Fatalf("DeleteRoleForUserInDomain = %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import "context"
+
+// The functions below manage resource role inheritance, i.e. the object groups of a model
+// with a second role definition such as "g2 = _, _" and a matcher like "g2(r.obj, p.obj)".
+// ptype is the name of that role definition, e.g. "g2", and the rules are "g2, resource, group".
+
+// AddResourceToGroup adds a resource to a group.
+// Returns false if the resource is already in the group (aka not affected).
+func (e *Enforcer) AddResourceToGroup(ctx context.Context, ptype string, resource string, group string) (bool, error) {
+	return e.AddNamedGroupingPolicy(ctx, ptype, resource, group)
+}
+
+// RemoveResourceFromGroup removes a resource from a group.
+// Returns false if the resource is not in the group (aka not affected).
+func (e *Enforcer) RemoveResourceFromGroup(ctx context.Context, ptype string, resource string, group string) (bool, error) {
+	return e.RemoveNamedGroupingPolicy(ctx, ptype, resource, group)
+}
+
+// GetGroupsForResource gets the groups that a resource is directly in.
+func (e *Enforcer) GetGroupsForResource(ctx context.Context, ptype string, resource string) ([]string, error) {
+	rules, err := e.GetFilteredNamedGroupingPolicy(ctx, ptype, 0, resource)
+	if err != nil {
+		return nil, err
+	}
+	return uniqueField(rules, 1), nil
+}
+
+// GetResourcesInGroup gets the resources and groups directly in a group.
+func (e *Enforcer) GetResourcesInGroup(ctx context.Context, ptype string, group string) ([]string, error) {
+	rules, err := e.GetFilteredNamedGroupingPolicy(ctx, ptype, 1, group)
+	if err != nil {
+		return nil, err
+	}
+	return uniqueField(rules, 0), nil
+}
+
+// GetImplicitGroupsForResource gets the groups that a resource is in, directly or through other groups.
+// For example:
+// g2, data1, data_group
+// g2, data_group, all_data
+//
+// GetGroupsForResource("g2", "data1") can only get: ["data_group"].
+// But GetImplicitGroupsForResource("g2", "data1") will get: ["all_data", "data_group"].
+//
+// casbin-server has no such query, so the rules of ptype are fetched once and walked by the client.
+// With a domain, only the rules of that domain are followed.
+func (e *Enforcer) GetImplicitGroupsForResource(ctx context.Context, ptype string, resource string, domain ...string) ([]string, error) {
+	ri, dom, err := e.resourceIndex(ctx, ptype, domain)
+	if err != nil {
+		return nil, err
+	}
+	return ri.roles(resource, dom, true), nil
+}
+
+// GetImplicitResourcesInGroup gets the resources and groups in a group, directly or through other groups.
+// For example:
+// g2, data1, data_group
+// g2, data_group, all_data
+//
+// GetResourcesInGroup("g2", "all_data") can only get: ["data_group"].
+// But GetImplicitResourcesInGroup("g2", "all_data") will get: ["data1", "data_group"].
+//
+// It is walked by the client like GetImplicitGroupsForResource.
+func (e *Enforcer) GetImplicitResourcesInGroup(ctx context.Context, ptype string, group string, domain ...string) ([]string, error) {
+	ri, dom, err := e.resourceIndex(ctx, ptype, domain)
+	if err != nil {
+		return nil, err
+	}
+	return ri.users(group, dom, true), nil
+}
+
+// resourceIndex indexes the rules of ptype, and returns the optional domain.
+func (e *Enforcer) resourceIndex(ctx context.Context, ptype string, domain []string) (*roleIndex, string, error) {
+	rules, err := e.GetNamedGroupingPolicyRules(ctx, ptype)
+	if err != nil {
+		return nil, "", err
+	}
+	dom := ""
+	if len(domain) > 0 {
+		dom = domain[0]
+	}
+	return newRoleIndex(rules), dom, nil
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestResourceGroups(t *testing.T) {
+	e := newTestEnforcer(t, rbacWithResourceRolesModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	for _, rule := range [][]string{{"data1", "data_group"}, {"data2", "data_group"}, {"data_group", "all_data"}} {
+		if ok, err := e.AddResourceToGroup(ctx, "g2", rule[0], rule[1]); !ok || err != nil {
+			t.Fatalf("AddResourceToGroup(%v) = %v, %v", rule, ok, err)
+		}
+	}
+
+	groups, err := e.GetGroupsForResource(ctx, "g2", "data1")
+	if err != nil || !stringsEqual(groups, "data_group") {
+		t.Errorf("GetGroupsForResource = %v, %v", groups, err)
+	}
+	groups, err = e.GetImplicitGroupsForResource(ctx, "g2", "data1")
+	if err != nil || !stringsEqual(groups, "all_data", "data_group") {
+		t.Errorf("GetImplicitGroupsForResource = %v, %v", groups, err)
+	}
+	resources, err := e.GetResourcesInGroup(ctx, "g2", "all_data")
+	if err != nil || !stringsEqual(resources, "data_group") {
+		t.Errorf("GetResourcesInGroup = %v, %v", resources, err)
+	}
+	resources, err = e.GetImplicitResourcesInGroup(ctx, "g2", "all_data")
+	if err != nil || !stringsEqual(resources, "data1", "data2", "data_group") {
+		t.Errorf("GetImplicitResourcesInGroup = %v, %v", resources, err)
+	}
+
+	if ok, err := e.RemoveResourceFromGroup(ctx, "g2", "data2", "data_group"); !ok || err != nil {
+		t.Errorf("RemoveResourceFromGroup = %v, %v", ok, err)
+	}
+	resources, err = e.GetResourcesInGroup(ctx, "g2", "data_group")
+	if err != nil || !stringsEqual(resources, "data1") {
+		t.Errorf("GetResourcesInGroup = %v, %v", resources, err)
+	}
+}
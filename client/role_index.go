@@ -0,0 +1,220 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import "sort"
+
+// roleEdge is a role inheritance rule "g, user, role[, domain]".
+type roleEdge struct {
+	user, role, domain string
+	rule               Rule
+}
+
+// roleIndex is an in-memory view of the role inheritance rules of one ptype,
+// used for the graph queries casbin-server does not offer.
+// Queries with an empty domain follow the rules of every domain.
+type roleIndex struct {
+	parents  map[string][]roleEdge
+	children map[string][]roleEdge
+}
+
+func newRoleIndex(rules []Rule) *roleIndex {
+	ri := &roleIndex{
+		parents:  make(map[string][]roleEdge),
+		children: make(map[string][]roleEdge),
+	}
+	for _, rule := range rules {
+		if len(rule.Values) < 2 {
+			continue
+		}
+		edge := roleEdge{user: rule.Values[0], role: rule.Values[1], rule: rule}
+		if len(rule.Values) > 2 {
+			edge.domain = rule.Values[2]
+		}
+		ri.parents[edge.user] = append(ri.parents[edge.user], edge)
+		ri.children[edge.role] = append(ri.children[edge.role], edge)
+	}
+	return ri
+}
+
+func inDomain(edge roleEdge, domain string) bool {
+	return domain == "" || edge.domain == domain
+}
+
+// roles returns the roles of user, including the inherited ones if implicit is set, sorted.
+func (ri *roleIndex) roles(user, domain string, implicit bool) []string {
+	return ri.walk(user, domain, implicit, ri.parents, func(edge roleEdge) string { return edge.role })
+}
+
+// users returns the users of role, including the indirect ones if implicit is set, sorted.
+func (ri *roleIndex) users(role, domain string, implicit bool) []string {
+	return ri.walk(role, domain, implicit, ri.children, func(edge roleEdge) string { return edge.user })
+}
+
+func (ri *roleIndex) walk(start, domain string, implicit bool, edges map[string][]roleEdge, next func(roleEdge) string) []string {
+	seen := map[string]bool{start: true}
+	queue := []string{start}
+	var result []string
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, edge := range edges[name] {
+			if !inDomain(edge, domain) || seen[next(edge)] {
+				continue
+			}
+			seen[next(edge)] = true
+			result = append(result, next(edge))
+			if implicit {
+				queue = append(queue, next(edge))
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// paths returns every inheritance path from user to role, as the sequences of edges followed,
+// without going through the same name twice. Paths are found depth first, in the order of the rules.
+func (ri *roleIndex) paths(user, role, domain string) [][]roleEdge {
+	var paths [][]roleEdge
+	var path []roleEdge
+	onPath := map[string]bool{user: true}
+	var visit func(name string)
+	visit = func(name string) {
+		for _, edge := range ri.parents[name] {
+			if !inDomain(edge, domain) || onPath[edge.role] {
+				continue
+			}
+			path = append(path, edge)
+			if edge.role == role {
+				paths = append(paths, append([]roleEdge(nil), path...))
+			} else {
+				onPath[edge.role] = true
+				visit(edge.role)
+				onPath[edge.role] = false
+			}
+			path = path[:len(path)-1]
+		}
+	}
+	visit(user)
+	return paths
+}
+
+// names returns every user and role of the rules, sorted.
+func (ri *roleIndex) names() []string {
+	seen := make(map[string]bool, len(ri.parents)+len(ri.children))
+	for name := range ri.parents {
+		seen[name] = true
+	}
+	for name := range ri.children {
+		seen[name] = true
+	}
+	return sortedKeys(seen)
+}
+
+// isRole reports whether name is the role of some rule.
+func (ri *roleIndex) isRole(name string) bool {
+	return len(ri.children[name]) > 0
+}
+
+// allRoles returns every name that is the role of some rule, sorted.
+func (ri *roleIndex) allRoles() []string {
+	roles := make([]string, 0, len(ri.children))
+	for role := range ri.children {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// cycles returns the groups of rules that form inheritance cycles, one group per strongly connected
+// set of names within a domain, e.g. the rules "g, a, b" and "g, b, a".
+func (ri *roleIndex) cycles() [][]roleEdge {
+	type node struct{ name, domain string }
+	adjacent := make(map[node][]roleEdge)
+	var nodes []node
+	for _, edges := range ri.parents {
+		for _, edge := range edges {
+			from := node{edge.user, edge.domain}
+			if _, ok := adjacent[from]; !ok {
+				nodes = append(nodes, from)
+			}
+			adjacent[from] = append(adjacent[from], edge)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].domain != nodes[j].domain {
+			return nodes[i].domain < nodes[j].domain
+		}
+		return nodes[i].name < nodes[j].name
+	})
+
+	// Tarjan's strongly connected components algorithm.
+	index := make(map[node]int)
+	low := make(map[node]int)
+	onStack := make(map[node]bool)
+	var stack []node
+	var groups [][]roleEdge
+	var connect func(n node)
+	connect = func(n node) {
+		index[n] = len(index)
+		low[n] = index[n]
+		stack = append(stack, n)
+		onStack[n] = true
+		for _, edge := range adjacent[n] {
+			to := node{edge.role, edge.domain}
+			if _, ok := index[to]; !ok {
+				connect(to)
+				if low[to] < low[n] {
+					low[n] = low[to]
+				}
+			} else if onStack[to] && index[to] < low[n] {
+				low[n] = index[to]
+			}
+		}
+		if low[n] != index[n] {
+			return
+		}
+
+		members := make(map[node]bool)
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			members[top] = true
+			if top == n {
+				break
+			}
+		}
+		var group []roleEdge
+		for member := range members {
+			for _, edge := range adjacent[member] {
+				if members[node{edge.role, edge.domain}] {
+					group = append(group, edge)
+				}
+			}
+		}
+		if len(group) > 0 {
+			sort.Slice(group, func(i, j int) bool { return group[i].rule.key() < group[j].rule.key() })
+			groups = append(groups, group)
+		}
+	}
+	for _, n := range nodes {
+		if _, ok := index[n]; !ok {
+			connect(n)
+		}
+	}
+	return groups
+}
@@ -0,0 +1,320 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// defaultPolicyTokens are the field names assumed for policy rules when the model is unknown.
+var defaultPolicyTokens = []string{"sub", "obj", "act"}
+
+// groupingTokens are the field names of role inheritance rules, which the model leaves unnamed ("g = _, _").
+var groupingTokens = []string{"user", "role", "domain"}
+
+// Rule is a policy or role inheritance rule together with its ptype.
+type Rule struct {
+	PType  string   `json:"ptype" yaml:"ptype"`
+	Values []string `json:"values" yaml:"values"`
+
+	// tokens are the field names of the rule, taken from the model's definition of PType.
+	tokens []string
+}
+
+// NewRule creates a rule of the given ptype, e.g. NewRule("p", "alice", "data1", "read").
+// Field names are resolved with the defaults ("sub", "obj", "act" for policy rules).
+func NewRule(ptype string, values ...string) Rule {
+	return Rule{PType: ptype, Values: values}
+}
+
+// IsGrouping reports whether the rule is a role inheritance rule ("g", "g2", ...).
+func (r Rule) IsGrouping() bool {
+	return isGroupingPType(r.PType)
+}
+
+// Tokens returns the field names of the rule.
+func (r Rule) Tokens() []string {
+	if r.tokens != nil {
+		return r.tokens
+	}
+	if r.IsGrouping() {
+		return groupingTokens
+	}
+	return defaultPolicyTokens
+}
+
+// Field returns the value of the field named name, e.g. "sub" or "obj".
+func (r Rule) Field(name string) (string, bool) {
+	for i, token := range r.Tokens() {
+		if token == name && i < len(r.Values) {
+			return r.Values[i], true
+		}
+	}
+	return "", false
+}
+
+func (r Rule) field(name string) string {
+	value, _ := r.Field(name)
+	return value
+}
+
+// Sub returns the subject of a policy rule.
+func (r Rule) Sub() string { return r.field("sub") }
+
+// Obj returns the object of a policy rule.
+func (r Rule) Obj() string { return r.field("obj") }
+
+// Act returns the action of a policy rule.
+func (r Rule) Act() string { return r.field("act") }
+
+// Dom returns the domain of a policy rule.
+func (r Rule) Dom() string { return r.field("dom") }
+
+// Eft returns the effect of a policy rule.
+func (r Rule) Eft() string { return r.field("eft") }
+
+// User returns the user (first field) of a role inheritance rule.
+func (r Rule) User() string { return r.field("user") }
+
+// Role returns the role (second field) of a role inheritance rule.
+func (r Rule) Role() string { return r.field("role") }
+
+// Domain returns the domain (third field) of a role inheritance rule.
+func (r Rule) Domain() string { return r.field("domain") }
+
+// Equal reports whether r and other have the same ptype and values.
+func (r Rule) Equal(other Rule) bool {
+	return r.key() == other.key()
+}
+
+// String formats the rule as a line of a casbin policy file, e.g. "p, alice, data1, read".
+func (r Rule) String() string {
+	return strings.Join(append([]string{r.PType}, r.Values...), ", ")
+}
+
+// key identifies the rule, for use as a map key.
+func (r Rule) key() string {
+	return r.PType + "\x00" + strings.Join(r.Values, "\x00")
+}
+
+// tokens returns the field names of ptype from the model, or nil if they are unknown.
+func (e *Enforcer) tokens(ptype string) []string {
+	if isGroupingPType(ptype) {
+		return groupingTokens
+	}
+	if e.model == nil {
+		return nil
+	}
+	ast, ok := e.model["p"][ptype]
+	if !ok {
+		return nil
+	}
+	tokens := make([]string, len(ast.Tokens))
+	for i, token := range ast.Tokens {
+		tokens[i] = strings.TrimPrefix(token, ptype+"_")
+	}
+	return tokens
+}
+
+// ptypes returns the policy and role inheritance ptypes defined by the model, e.g. ["p", "p2", "g"].
+// If the model is unknown, only the default "p" and "g" are returned.
+func (e *Enforcer) ptypes() []string {
+	if e.model == nil {
+		return []string{"p", "g"}
+	}
+	var ptypes []string
+	for _, sec := range []string{"p", "g"} {
+		keys := make([]string, 0, len(e.model[sec]))
+		for ptype := range e.model[sec] {
+			keys = append(keys, ptype)
+		}
+		sort.Strings(keys)
+		ptypes = append(ptypes, keys...)
+	}
+	return ptypes
+}
+
+// fieldIndex returns the position of the field named name in rules of ptype.
+func (e *Enforcer) fieldIndex(ptype, name string) (int, bool) {
+	tokens := e.tokens(ptype)
+	if tokens == nil {
+		tokens = defaultPolicyTokens
+	}
+	for i, token := range tokens {
+		if token == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// subjectIndex returns the position of the "sub" field in "p" rules, 0 if the model is unknown.
+func (e *Enforcer) subjectIndex() (int, error) {
+	index, ok := e.fieldIndex("p", "sub")
+	if !ok {
+		return 0, errors.New(`the policy_definition of the model has no "sub" field`)
+	}
+	return index, nil
+}
+
+// newRule creates a rule of ptype whose field names are taken from the model.
+func (e *Enforcer) newRule(ptype string, values []string) Rule {
+	return Rule{PType: ptype, Values: values, tokens: e.tokens(ptype)}
+}
+
+// newRules wraps rules of ptype into Rule values.
+func (e *Enforcer) newRules(ptype string, rules [][]string) []Rule {
+	result := make([]Rule, len(rules))
+	for i, values := range rules {
+		result[i] = e.newRule(ptype, values)
+	}
+	return result
+}
+
+// GetPolicyRules gets all the authorization rules in the policy.
+func (e *Enforcer) GetPolicyRules(ctx context.Context) ([]Rule, error) {
+	return e.GetNamedPolicyRules(ctx, "p")
+}
+
+// GetNamedPolicyRules gets all the authorization rules in the named policy.
+func (e *Enforcer) GetNamedPolicyRules(ctx context.Context, ptype string) ([]Rule, error) {
+	rules, err := e.GetNamedPolicy(ctx, ptype)
+	if err != nil {
+		return nil, err
+	}
+	return e.newRules(ptype, rules), nil
+}
+
+// GetFilteredPolicyRules gets all the authorization rules in the policy, field filters can be specified.
+func (e *Enforcer) GetFilteredPolicyRules(ctx context.Context, fieldIndex int32, fieldValues ...string) ([]Rule, error) {
+	return e.GetFilteredNamedPolicyRules(ctx, "p", fieldIndex, fieldValues...)
+}
+
+// GetFilteredNamedPolicyRules gets all the authorization rules in the named policy, field filters can be specified.
+func (e *Enforcer) GetFilteredNamedPolicyRules(ctx context.Context, ptype string, fieldIndex int32, fieldValues ...string) ([]Rule, error) {
+	rules, err := e.GetFilteredNamedPolicy(ctx, ptype, fieldIndex, fieldValues...)
+	if err != nil {
+		return nil, err
+	}
+	return e.newRules(ptype, rules), nil
+}
+
+// GetGroupingPolicyRules gets all the role inheritance rules in the policy.
+func (e *Enforcer) GetGroupingPolicyRules(ctx context.Context) ([]Rule, error) {
+	return e.GetNamedGroupingPolicyRules(ctx, "g")
+}
+
+// GetNamedGroupingPolicyRules gets all the role inheritance rules in the named policy.
+func (e *Enforcer) GetNamedGroupingPolicyRules(ctx context.Context, ptype string) ([]Rule, error) {
+	rules, err := e.GetNamedGroupingPolicy(ctx, ptype)
+	if err != nil {
+		return nil, err
+	}
+	return e.newRules(ptype, rules), nil
+}
+
+// GetFilteredGroupingPolicyRules gets all the role inheritance rules in the policy, field filters can be specified.
+func (e *Enforcer) GetFilteredGroupingPolicyRules(ctx context.Context, fieldIndex int32, fieldValues ...string) ([]Rule, error) {
+	return e.GetFilteredNamedGroupingPolicyRules(ctx, "g", fieldIndex, fieldValues...)
+}
+
+// GetFilteredNamedGroupingPolicyRules gets all the role inheritance rules in the named policy,
+// field filters can be specified.
+func (e *Enforcer) GetFilteredNamedGroupingPolicyRules(ctx context.Context, ptype string, fieldIndex int32, fieldValues ...string) ([]Rule, error) {
+	rules, err := e.GetFilteredNamedGroupingPolicy(ctx, ptype, fieldIndex, fieldValues...)
+	if err != nil {
+		return nil, err
+	}
+	return e.newRules(ptype, rules), nil
+}
+
+// getRules gets all the rules of the given ptypes.
+func (e *Enforcer) getRules(ctx context.Context, ptypes []string) ([]Rule, error) {
+	var rules []Rule
+	for _, ptype := range ptypes {
+		var batch []Rule
+		var err error
+		if isGroupingPType(ptype) {
+			batch, err = e.GetNamedGroupingPolicyRules(ctx, ptype)
+		} else {
+			batch, err = e.GetNamedPolicyRules(ctx, ptype)
+		}
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, batch...)
+	}
+	return rules, nil
+}
+
+// HasRule determines whether a rule exists.
+func (e *Enforcer) HasRule(ctx context.Context, rule Rule) (bool, error) {
+	if rule.IsGrouping() {
+		return e.HasNamedGroupingPolicy(ctx, rule.PType, rule.Values)
+	}
+	return e.HasNamedPolicy(ctx, rule.PType, rule.Values)
+}
+
+// AddRule adds a rule to the current policy, as AddNamedPolicy or AddNamedGroupingPolicy depending on its ptype.
+func (e *Enforcer) AddRule(ctx context.Context, rule Rule) (bool, error) {
+	if rule.IsGrouping() {
+		return e.AddNamedGroupingPolicy(ctx, rule.PType, rule.Values)
+	}
+	return e.AddNamedPolicy(ctx, rule.PType, rule.Values)
+}
+
+// RemoveRule removes a rule from the current policy, as RemoveNamedPolicy or RemoveNamedGroupingPolicy
+// depending on its ptype.
+func (e *Enforcer) RemoveRule(ctx context.Context, rule Rule) (bool, error) {
+	if rule.IsGrouping() {
+		return e.RemoveNamedGroupingPolicy(ctx, rule.PType, rule.Values)
+	}
+	return e.RemoveNamedPolicy(ctx, rule.PType, rule.Values)
+}
+
+// AddRules adds rules to the current policy one by one.
+// It returns the number of rules reported as added, and stops at the first error.
+func (e *Enforcer) AddRules(ctx context.Context, rules []Rule) (int, error) {
+	count := 0
+	for _, rule := range rules {
+		ok, err := e.AddRule(ctx, rule)
+		if err != nil {
+			return count, err
+		}
+		if ok {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// RemoveRules removes rules from the current policy one by one.
+// It returns the number of rules that were removed, and stops at the first error.
+func (e *Enforcer) RemoveRules(ctx context.Context, rules []Rule) (int, error) {
+	count := 0
+	for _, rule := range rules {
+		ok, err := e.RemoveRule(ctx, rule)
+		if err != nil {
+			return count, err
+		}
+		if ok {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/casbin/casbin/v2/model"
+)
+
+func TestRuleFields(t *testing.T) {
+	m, err := model.NewModelFromString(`
+[request_definition]
+r = sub, dom, obj, act
+
+[policy_definition]
+p = sub, dom, obj, act, eft
+
+[role_definition]
+g = _, _, _
+
+[policy_effect]
+e = some(where (p.eft == allow)) && !some(where (p.eft == deny))
+
+[matchers]
+m = g(r.sub, p.sub, r.dom) && r.dom == p.dom && r.obj == p.obj && r.act == p.act
+`)
+	if err != nil {
+		t.Fatalf("NewModelFromString err: %v", err)
+	}
+	e := &Enforcer{model: m}
+
+	p := e.newRule("p", []string{"alice", "tenant1", "data1", "read", "deny"})
+	if p.Sub() != "alice" || p.Dom() != "tenant1" || p.Obj() != "data1" || p.Act() != "read" || p.Eft() != "deny" {
+		t.Errorf("Rule %v: fields resolved as %q %q %q %q %q", p, p.Sub(), p.Dom(), p.Obj(), p.Act(), p.Eft())
+	}
+
+	g := e.newRule("g", []string{"alice", "admin", "tenant1"})
+	if !g.IsGrouping() || g.User() != "alice" || g.Role() != "admin" || g.Domain() != "tenant1" {
+		t.Errorf("Rule %v: fields resolved as %q %q %q", g, g.User(), g.Role(), g.Domain())
+	}
+
+	d := NewRule("p", "bob", "data2", "write")
+	if d.Sub() != "bob" || d.Obj() != "data2" || d.Act() != "write" || d.Dom() != "" {
+		t.Errorf("Rule %v: default fields resolved as %q %q %q %q", d, d.Sub(), d.Obj(), d.Act(), d.Dom())
+	}
+	if d.String() != "p, bob, data2, write" {
+		t.Errorf("String() = %q", d.String())
+	}
+	if !d.Equal(e.newRule("p", []string{"bob", "data2", "write"})) {
+		t.Errorf("Equal() = false for rules with the same values")
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// Snapshot is the state of every ptype of a policy at a point in time.
+type Snapshot struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Hash is the content hash of the rules, see HashRules.
+	Hash string `json:"hash"`
+	// PTypes lists the captured ptypes, including those that had no rules.
+	PTypes []string `json:"ptypes"`
+	// Policies holds the rule values keyed by ptype.
+	Policies map[string][][]string `json:"policies"`
+}
+
+// Rules returns the rules of the snapshot.
+func (s *Snapshot) Rules() []Rule {
+	var rules []Rule
+	for _, ptype := range s.PTypes {
+		for _, values := range s.Policies[ptype] {
+			rules = append(rules, NewRule(ptype, values...))
+		}
+	}
+	return rules
+}
+
+// Verify checks that the snapshot content matches its hash.
+func (s *Snapshot) Verify() error {
+	if hash := HashRules(s.Rules()); hash != s.Hash {
+		return fmt.Errorf("snapshot hash mismatch: content hashes to %s, recorded %s", hash, s.Hash)
+	}
+	return nil
+}
+
+// Save writes the snapshot to a JSON file.
+func (s *Snapshot) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// LoadSnapshot reads a snapshot written by Snapshot.Save and verifies its hash.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Snapshot{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, s.Verify()
+}
+
+// HashRules returns a content hash of rules that does not depend on their order or duplicates,
+// e.g. "sha256:3b2c...".
+func HashRules(rules []Rule) string {
+	keys := make([]string, 0, len(rules))
+	seen := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if key := rule.key(); !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		h.Write([]byte(key))
+		h.Write([]byte{'\n'})
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// Snapshot captures the rules of every ptype of the model.
+func (e *Enforcer) Snapshot(ctx context.Context) (*Snapshot, error) {
+	ptypes := e.ptypes()
+	rules, err := e.getRules(ctx, ptypes)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Snapshot{
+		Timestamp: time.Now().UTC(),
+		Hash:      HashRules(rules),
+		PTypes:    ptypes,
+		Policies:  make(map[string][][]string),
+	}
+	for _, rule := range rules {
+		s.Policies[rule.PType] = append(s.Policies[rule.PType], rule.Values)
+	}
+	return s, nil
+}
+
+// Restore brings the captured ptypes back to the state of the snapshot,
+// by adding and removing only the rules that changed since.
+func (e *Enforcer) Restore(ctx context.Context, s *Snapshot) (*ApplyReport, error) {
+	if err := s.Verify(); err != nil {
+		return nil, err
+	}
+	return e.Apply(ctx, s.Rules(), ApplyOptions{Prune: true, PTypes: s.PTypes})
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("p", "alice", "data1", "read"),
+		NewRule("p", "bob", "data2", "write"),
+		NewRule("g", "alice", "admin"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+
+	snapshot, err := e.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Snapshot err: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := snapshot.Save(path); err != nil {
+		t.Fatalf("Save err: %v", err)
+	}
+
+	if _, err := e.RemoveFilteredPolicy(ctx, 0, "alice"); err != nil {
+		t.Fatalf("RemoveFilteredPolicy err: %v", err)
+	}
+	if _, err := e.AddPolicy(ctx, "eve", "data1", "write"); err != nil {
+		t.Fatalf("AddPolicy err: %v", err)
+	}
+
+	loaded, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadSnapshot err: %v", err)
+	}
+	report, err := e.Restore(ctx, loaded)
+	if err != nil {
+		t.Fatalf("Restore err: %v", err)
+	}
+	if len(report.Added) != 1 || len(report.Removed) != 1 || report.Unchanged != 2 {
+		t.Errorf("Restore report: %+v", report)
+	}
+
+	again, err := e.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Snapshot err: %v", err)
+	}
+	if again.Hash != snapshot.Hash {
+		t.Errorf("hash after restore: %s, supposed to be %s", again.Hash, snapshot.Hash)
+	}
+
+	loaded.Policies["p"] = loaded.Policies["p"][1:]
+	if err := loaded.Verify(); err == nil {
+		t.Errorf("Verify accepted a tampered snapshot")
+	}
+}
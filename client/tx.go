@@ -0,0 +1,341 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TxAction is the kind of mutation recorded by a PolicyTx.
+type TxAction string
+
+const (
+	// TxAdd adds a rule.
+	TxAdd TxAction = "add"
+	// TxRemove removes a rule.
+	TxRemove TxAction = "remove"
+	// TxRemoveFiltered removes the rules whose fields from FieldIndex on equal Rule, an empty value matching
+	// any field. Tx expands it into one TxRemove per matching rule before applying it.
+	TxRemoveFiltered TxAction = "remove-filtered"
+)
+
+// TxOp is a single rule mutation recorded by a PolicyTx.
+type TxOp struct {
+	Action     TxAction
+	PType      string
+	Rule       []string
+	FieldIndex int
+	// Affected reports whether the server actually changed the policy when the operation was applied.
+	Affected bool
+}
+
+// inverse returns the operation that undoes op.
+func (op TxOp) inverse() TxOp {
+	inv := TxOp{PType: op.PType, Rule: op.Rule}
+	if op.Action == TxAdd {
+		inv.Action = TxRemove
+	} else {
+		inv.Action = TxAdd
+	}
+	return inv
+}
+
+func (op TxOp) String() string {
+	if op.Action == TxRemoveFiltered {
+		return fmt.Sprintf("%s %s[%d:], %s", op.Action, op.PType, op.FieldIndex, strings.Join(op.Rule, ", "))
+	}
+	return fmt.Sprintf("%s %s, %s", op.Action, op.PType, strings.Join(op.Rule, ", "))
+}
+
+// TxReport describes what a transaction changed on the server.
+type TxReport struct {
+	// Applied lists the operations that were sent to the server, in order.
+	// Filtered removals are listed as the removals of the rules they matched.
+	Applied []TxOp
+	// Reverted lists the inverse operations that were replayed during rollback, in order.
+	Reverted []TxOp
+}
+
+// TxError is returned by Tx when an operation fails while being applied.
+type TxError struct {
+	// Op is the operation that failed.
+	Op TxOp
+	// Err is the error returned by the server for Op.
+	Err error
+	// RollbackErrs holds the errors hit while reverting the operations applied before Op.
+	RollbackErrs []error
+}
+
+func (e *TxError) Error() string {
+	msg := fmt.Sprintf("transaction failed at %q: %v", e.Op.String(), e.Err)
+	if len(e.RollbackErrs) > 0 {
+		msg += fmt.Sprintf(" (rollback incomplete, %d errors, first: %v)", len(e.RollbackErrs), e.RollbackErrs[0])
+	}
+	return msg
+}
+
+func (e *TxError) Unwrap() error {
+	return e.Err
+}
+
+// PolicyTx records the rule mutations of a transaction started by Enforcer.Tx.
+// Nothing is sent to the server until the transaction function returns.
+type PolicyTx struct {
+	ops []TxOp
+	// err is the first error hit while recording, returned by Tx before anything is applied.
+	err error
+}
+
+func (tx *PolicyTx) record(action TxAction, ptype string, rule []string) {
+	tx.ops = append(tx.ops, TxOp{Action: action, PType: ptype, Rule: rule})
+}
+
+func (tx *PolicyTx) recordParams(action TxAction, ptype string, params []interface{}) {
+	rule, err := paramsToStrSlice(params)
+	if err != nil {
+		if tx.err == nil {
+			tx.err = err
+		}
+		return
+	}
+	tx.record(action, ptype, rule)
+}
+
+// AddPolicy records adding an authorization rule to the current policy.
+func (tx *PolicyTx) AddPolicy(params ...interface{}) {
+	tx.recordParams(TxAdd, "p", params)
+}
+
+// AddNamedPolicy records adding an authorization rule to the current named policy.
+func (tx *PolicyTx) AddNamedPolicy(ptype string, params ...interface{}) {
+	tx.recordParams(TxAdd, ptype, params)
+}
+
+// RemovePolicy records removing an authorization rule from the current policy.
+func (tx *PolicyTx) RemovePolicy(params ...interface{}) {
+	tx.recordParams(TxRemove, "p", params)
+}
+
+// RemoveNamedPolicy records removing an authorization rule from the current named policy.
+func (tx *PolicyTx) RemoveNamedPolicy(ptype string, params ...interface{}) {
+	tx.recordParams(TxRemove, ptype, params)
+}
+
+// AddGroupingPolicy records adding a role inheritance rule to the current policy.
+func (tx *PolicyTx) AddGroupingPolicy(params ...interface{}) {
+	tx.recordParams(TxAdd, "g", params)
+}
+
+// AddNamedGroupingPolicy records adding a named role inheritance rule to the current policy.
+func (tx *PolicyTx) AddNamedGroupingPolicy(ptype string, params ...interface{}) {
+	tx.recordParams(TxAdd, ptype, params)
+}
+
+// RemoveGroupingPolicy records removing a role inheritance rule from the current policy.
+func (tx *PolicyTx) RemoveGroupingPolicy(params ...interface{}) {
+	tx.recordParams(TxRemove, "g", params)
+}
+
+// RemoveNamedGroupingPolicy records removing a role inheritance rule from the current named policy.
+func (tx *PolicyTx) RemoveNamedGroupingPolicy(ptype string, params ...interface{}) {
+	tx.recordParams(TxRemove, ptype, params)
+}
+
+// AddRoleForUser records adding a role for a user.
+func (tx *PolicyTx) AddRoleForUser(user, role string) {
+	tx.record(TxAdd, "g", []string{user, role})
+}
+
+// DeleteRoleForUser records deleting a role for a user.
+func (tx *PolicyTx) DeleteRoleForUser(user, role string) {
+	tx.record(TxRemove, "g", []string{user, role})
+}
+
+// AddPermissionForUser records adding a permission for a user or role.
+func (tx *PolicyTx) AddPermissionForUser(user string, permission ...string) {
+	tx.record(TxAdd, "p", append([]string{user}, permission...))
+}
+
+// DeletePermissionForUser records deleting a permission for a user or role.
+func (tx *PolicyTx) DeletePermissionForUser(user string, permission ...string) {
+	tx.record(TxRemove, "p", append([]string{user}, permission...))
+}
+
+func (tx *PolicyTx) recordFiltered(ptype string, fieldIndex int, fieldValues []string) {
+	tx.ops = append(tx.ops, TxOp{Action: TxRemoveFiltered, PType: ptype, Rule: fieldValues, FieldIndex: fieldIndex})
+}
+
+// RemoveFilteredPolicy records removing the authorization rules matching the field filters.
+func (tx *PolicyTx) RemoveFilteredPolicy(fieldIndex int, fieldValues ...string) {
+	tx.recordFiltered("p", fieldIndex, fieldValues)
+}
+
+// RemoveFilteredNamedPolicy records removing the named authorization rules matching the field filters.
+func (tx *PolicyTx) RemoveFilteredNamedPolicy(ptype string, fieldIndex int, fieldValues ...string) {
+	tx.recordFiltered(ptype, fieldIndex, fieldValues)
+}
+
+// RemoveFilteredGroupingPolicy records removing the role inheritance rules matching the field filters.
+func (tx *PolicyTx) RemoveFilteredGroupingPolicy(fieldIndex int, fieldValues ...string) {
+	tx.recordFiltered("g", fieldIndex, fieldValues)
+}
+
+// RemoveFilteredNamedGroupingPolicy records removing the named role inheritance rules matching the field filters.
+func (tx *PolicyTx) RemoveFilteredNamedGroupingPolicy(ptype string, fieldIndex int, fieldValues ...string) {
+	tx.recordFiltered(ptype, fieldIndex, fieldValues)
+}
+
+// DeleteRolesForUser records deleting all roles for a user.
+func (tx *PolicyTx) DeleteRolesForUser(user string) {
+	tx.recordFiltered("g", 0, []string{user})
+}
+
+// DeletePermissionsForUser records deleting all permissions for a user or role.
+func (tx *PolicyTx) DeletePermissionsForUser(user string) {
+	tx.recordFiltered("p", 0, []string{user})
+}
+
+// DeleteUser records deleting a user, as casbin does: its roles and its permissions.
+func (tx *PolicyTx) DeleteUser(user string) {
+	tx.recordFiltered("g", 0, []string{user})
+	tx.recordFiltered("p", 0, []string{user})
+}
+
+// DeleteRole records deleting a role, as casbin does: its parent roles, its members and its permissions.
+func (tx *PolicyTx) DeleteRole(role string) {
+	tx.recordFiltered("g", 0, []string{role})
+	tx.recordFiltered("g", 1, []string{role})
+	tx.recordFiltered("p", 0, []string{role})
+}
+
+// Tx runs fn to record a sequence of rule mutations and then applies them in order.
+// If fn returns an error, or a recorded operation has invalid params, nothing is sent to the server.
+// If an operation fails, the operations applied so far are undone in reverse order by replaying
+// their inverse, skipping those that did not affect the policy (e.g. adding a rule that already existed),
+// and a *TxError is returned. Rollback uses ctx, so it cannot run once ctx is done.
+//
+// The returned report lists what was applied and what was reverted, and is non-nil whenever fn succeeded.
+// Other clients may observe the intermediate states, as casbin-server has no transaction support.
+func (e *Enforcer) Tx(ctx context.Context, fn func(tx *PolicyTx) error) (*TxReport, error) {
+	tx := &PolicyTx{}
+	if err := fn(tx); err != nil {
+		return nil, err
+	}
+	if tx.err != nil {
+		return nil, tx.err
+	}
+
+	report := &TxReport{}
+	for _, recorded := range tx.ops {
+		ops := []TxOp{recorded}
+		if recorded.Action == TxRemoveFiltered {
+			var err error
+			ops, err = e.expandFiltered(ctx, recorded)
+			if err != nil {
+				return report, &TxError{Op: recorded, Err: err, RollbackErrs: e.rollback(ctx, report)}
+			}
+		}
+		for _, op := range ops {
+			affected, err := e.applyTxOp(ctx, op)
+			if err != nil {
+				return report, &TxError{Op: op, Err: err, RollbackErrs: e.rollback(ctx, report)}
+			}
+			op.Affected = affected
+			report.Applied = append(report.Applied, op)
+		}
+	}
+	return report, nil
+}
+
+// expandFiltered turns a filtered removal into the removals of the rules it currently matches,
+// so that each of them can be reverted.
+func (e *Enforcer) expandFiltered(ctx context.Context, op TxOp) ([]TxOp, error) {
+	var rules [][]string
+	var err error
+	if isGroupingPType(op.PType) {
+		rules, err = e.GetFilteredNamedGroupingPolicy(ctx, op.PType, int32(op.FieldIndex), op.Rule...)
+	} else {
+		rules, err = e.GetFilteredNamedPolicy(ctx, op.PType, int32(op.FieldIndex), op.Rule...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return removeOps(op.PType, rules), nil
+}
+
+// removeOps returns the operations removing rules of ptype.
+func removeOps(ptype string, rules [][]string) []TxOp {
+	ops := make([]TxOp, len(rules))
+	for i, rule := range rules {
+		ops[i] = TxOp{Action: TxRemove, PType: ptype, Rule: rule}
+	}
+	return ops
+}
+
+// rollback replays the inverse of the affected operations in report.Applied, newest first.
+func (e *Enforcer) rollback(ctx context.Context, report *TxReport) []error {
+	var errs []error
+	for i := len(report.Applied) - 1; i >= 0; i-- {
+		if !report.Applied[i].Affected {
+			continue
+		}
+		inv := report.Applied[i].inverse()
+		affected, err := e.applyTxOp(ctx, inv)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("revert %q: %w", report.Applied[i].String(), err))
+			continue
+		}
+		inv.Affected = affected
+		report.Reverted = append(report.Reverted, inv)
+	}
+	return errs
+}
+
+// applyTxOp sends op to the server.
+// The server reports adding an existing rule as a change, so rules are looked up first
+// to tell whether an add really affects the policy.
+func (e *Enforcer) applyTxOp(ctx context.Context, op TxOp) (bool, error) {
+	if op.Action == TxAdd {
+		var exists bool
+		var err error
+		if isGroupingPType(op.PType) {
+			exists, err = e.HasNamedGroupingPolicy(ctx, op.PType, op.Rule)
+		} else {
+			exists, err = e.HasNamedPolicy(ctx, op.PType, op.Rule)
+		}
+		if exists || err != nil {
+			return false, err
+		}
+	}
+
+	switch {
+	case op.Action == TxAdd && isGroupingPType(op.PType):
+		return e.AddNamedGroupingPolicy(ctx, op.PType, op.Rule)
+	case op.Action == TxAdd:
+		return e.AddNamedPolicy(ctx, op.PType, op.Rule)
+	case op.Action == TxRemove && isGroupingPType(op.PType):
+		return e.RemoveNamedGroupingPolicy(ctx, op.PType, op.Rule)
+	case op.Action == TxRemove:
+		return e.RemoveNamedPolicy(ctx, op.PType, op.Rule)
+	}
+	return false, fmt.Errorf("unknown transaction action %q", op.Action)
+}
+
+// isGroupingPType reports whether ptype names a role inheritance rule, e.g. "g" or "g2".
+func isGroupingPType(ptype string) bool {
+	return strings.HasPrefix(ptype, "g")
+}
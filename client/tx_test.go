@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const rbacModelText = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
+`
+
+// newTestEnforcer creates an enforcer with an empty policy on the test server.
+func newTestEnforcer(t *testing.T, modelText string) *Enforcer {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	cc, err := NewClient(ctx, address, grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("connot create client: %v", err)
+	}
+
+	enforcer, err := cc.NewEnforcer(ctx, Config{ModelText: modelText})
+	if err != nil {
+		t.Fatalf("NewEnforcer() error: %v", err)
+	}
+	return enforcer
+}
+
+func TestTxCommit(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	report, err := e.Tx(ctx, func(tx *PolicyTx) error {
+		tx.AddGroupingPolicy("alice", "admin")
+		tx.AddPermissionForUser("admin", "data1", "read")
+		tx.AddPermissionForUser("admin", "data1", "read")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Tx err: %v", err)
+	}
+	if len(report.Applied) != 3 || !report.Applied[1].Affected || report.Applied[2].Affected {
+		t.Errorf("Applied: %v", report.Applied)
+	}
+
+	ok, err := e.Enforce(ctx, "alice", "data1", "read")
+	if err != nil || !ok {
+		t.Errorf("Enforce: %v, %v, supposed to be true", ok, err)
+	}
+}
+
+func TestTxRollback(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddPolicy(ctx, "bob", "data2", "write"); err != nil {
+		t.Fatalf("AddPolicy err: %v", err)
+	}
+
+	report, err := e.Tx(ctx, func(tx *PolicyTx) error {
+		tx.AddRoleForUser("bob", "admin")
+		tx.AddPolicy("bob", "data2", "write")
+		tx.AddPermissionForUser("admin", "data1", "read")
+		tx.AddNamedPolicy("p9", "admin", "data1", "write")
+		return nil
+	})
+	var txErr *TxError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("Tx err: %v, supposed to be a *TxError", err)
+	}
+	if len(txErr.RollbackErrs) != 0 {
+		t.Fatalf("RollbackErrs: %v", txErr.RollbackErrs)
+	}
+	if len(report.Applied) != 3 || len(report.Reverted) != 2 {
+		t.Errorf("Applied: %v, Reverted: %v", report.Applied, report.Reverted)
+	}
+
+	policies, err := e.GetPolicy(ctx)
+	if err != nil {
+		t.Fatalf("GetPolicy err: %v", err)
+	}
+	testGetPolicy(t, policies, [][]string{{"bob", "data2", "write"}})
+
+	groupings, err := e.GetGroupingPolicy(ctx)
+	if err != nil {
+		t.Fatalf("GetGroupingPolicy err: %v", err)
+	}
+	testGetPolicy(t, groupings, [][]string{})
+}
+
+func TestTxRecordError(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	recordErr := errors.New("abort")
+	report, err := e.Tx(ctx, func(tx *PolicyTx) error {
+		tx.AddPolicy("carol", "data3", "read")
+		return recordErr
+	})
+	if err != recordErr || report != nil {
+		t.Fatalf("Tx: %v, %v, supposed to return the record error", report, err)
+	}
+
+	ok, err := e.HasPolicy(ctx, "carol", "data3", "read")
+	if err != nil || ok {
+		t.Errorf("HasPolicy: %v, %v, supposed to be false", ok, err)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrConflict is the error wrapped by ConflictError.
+var ErrConflict = errors.New("policy version conflict")
+
+// ConflictError is returned by the *IfVersion methods when the policy changed since the expected version was read.
+type ConflictError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%v: expected %s, found %s", ErrConflict, e.Expected, e.Actual)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// PolicyVersion returns the version of the rules matching the filter, or of the whole policy if f is nil.
+// The version is the content hash of the rules, see HashRules, so it changes exactly when the rules do.
+func (e *Enforcer) PolicyVersion(ctx context.Context, f *PolicyFilter) (string, error) {
+	var rules []Rule
+	var err error
+	if f == nil {
+		rules, err = e.getRules(ctx, e.ptypes())
+	} else {
+		rules, err = e.GetRulesByFilter(ctx, f)
+	}
+	if err != nil {
+		return "", err
+	}
+	return HashRules(rules), nil
+}
+
+// PermissionsVersion returns the version of the permissions of a user or role,
+// to pass to AddPermissionForUserIfVersion and DeletePermissionForUserIfVersion.
+// The rules are those whose "sub" field, as named by the model, is user.
+func (e *Enforcer) PermissionsVersion(ctx context.Context, user string) (string, error) {
+	index, err := e.subjectIndex()
+	if err != nil {
+		return "", err
+	}
+	rules, err := e.GetFilteredPolicyRules(ctx, int32(index), user)
+	if err != nil {
+		return "", err
+	}
+	return HashRules(rules), nil
+}
+
+// RolesVersion returns the version of the roles of a user,
+// to pass to AddRoleForUserIfVersion and DeleteRoleForUserIfVersion.
+func (e *Enforcer) RolesVersion(ctx context.Context, user string) (string, error) {
+	rules, err := e.GetFilteredGroupingPolicyRules(ctx, 0, user)
+	if err != nil {
+		return "", err
+	}
+	return HashRules(rules), nil
+}
+
+func checkVersion(expected, actual string) error {
+	if expected != actual {
+		return &ConflictError{Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
+// AddPermissionForUserIfVersion is AddPermissionForUser, failing with a *ConflictError
+// if the permissions of user are no longer at version, as returned by PermissionsVersion.
+//
+// The version is checked right before the change is sent, but casbin-server cannot make the check and the change
+// atomic, so a concurrent edit in between is still possible. The check catches edits based on stale reads,
+// which are by far the most common conflict.
+func (e *Enforcer) AddPermissionForUserIfVersion(ctx context.Context, version, user string, permission ...string) (bool, error) {
+	actual, err := e.PermissionsVersion(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	if err := checkVersion(version, actual); err != nil {
+		return false, err
+	}
+	return e.AddPermissionForUser(ctx, user, permission...)
+}
+
+// DeletePermissionForUserIfVersion is DeletePermissionForUser, failing with a *ConflictError
+// if the permissions of user are no longer at version, as returned by PermissionsVersion.
+// See AddPermissionForUserIfVersion for the guarantees.
+func (e *Enforcer) DeletePermissionForUserIfVersion(ctx context.Context, version, user string, permission ...string) (bool, error) {
+	actual, err := e.PermissionsVersion(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	if err := checkVersion(version, actual); err != nil {
+		return false, err
+	}
+	return e.DeletePermissionForUser(ctx, user, permission...)
+}
+
+// AddRoleForUserIfVersion is AddRoleForUser, failing with a *ConflictError
+// if the roles of user are no longer at version, as returned by RolesVersion.
+// See AddPermissionForUserIfVersion for the guarantees.
+func (e *Enforcer) AddRoleForUserIfVersion(ctx context.Context, version, user, role string) (bool, error) {
+	actual, err := e.RolesVersion(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	if err := checkVersion(version, actual); err != nil {
+		return false, err
+	}
+	return e.AddRoleForUser(ctx, user, role)
+}
+
+// DeleteRoleForUserIfVersion is DeleteRoleForUser, failing with a *ConflictError
+// if the roles of user are no longer at version, as returned by RolesVersion.
+// See AddPermissionForUserIfVersion for the guarantees.
+func (e *Enforcer) DeleteRoleForUserIfVersion(ctx context.Context, version, user, role string) (bool, error) {
+	actual, err := e.RolesVersion(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	if err := checkVersion(version, actual); err != nil {
+		return false, err
+	}
+	return e.DeleteRoleForUser(ctx, user, role)
+}
+
+// TxIfVersion is Tx, failing with a *ConflictError before anything is applied
+// if the rules matching the filter are no longer at version, as returned by PolicyVersion.
+// See AddPermissionForUserIfVersion for the guarantees.
+func (e *Enforcer) TxIfVersion(ctx context.Context, f *PolicyFilter, version string, fn func(tx *PolicyTx) error) (*TxReport, error) {
+	actual, err := e.PolicyVersion(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(version, actual); err != nil {
+		return nil, err
+	}
+	return e.Tx(ctx, fn)
+}
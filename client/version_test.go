@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// objFirstModelText is an RBAC model whose policy rules do not start with the subject.
+const objFirstModelText = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = obj, sub, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
+`
+
+func TestIfVersion(t *testing.T) {
+	e := newTestEnforcer(t, rbacModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	version, err := e.PermissionsVersion(ctx, "admin")
+	if err != nil {
+		t.Fatalf("PermissionsVersion err: %v", err)
+	}
+	if ok, err := e.AddPermissionForUserIfVersion(ctx, version, "admin", "data1", "read"); !ok || err != nil {
+		t.Fatalf("AddPermissionForUserIfVersion = %v, %v", ok, err)
+	}
+
+	// The version read before the first edit is stale.
+	_, err = e.AddPermissionForUserIfVersion(ctx, version, "admin", "data1", "write")
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || !errors.Is(err, ErrConflict) {
+		t.Fatalf("AddPermissionForUserIfVersion err = %v, supposed to be a conflict", err)
+	}
+	if conflict.Actual == version {
+		t.Errorf("the conflict reports the expected version as the actual one")
+	}
+
+	if ok, err := e.DeletePermissionForUserIfVersion(ctx, conflict.Actual, "admin", "data1", "read"); !ok || err != nil {
+		t.Fatalf("DeletePermissionForUserIfVersion = %v, %v", ok, err)
+	}
+	current, err := e.PermissionsVersion(ctx, "admin")
+	if err != nil || current != version {
+		t.Errorf("PermissionsVersion = %s, %v, supposed to be back to %s", current, err, version)
+	}
+}
+
+func TestPermissionsVersionSubjectIndex(t *testing.T) {
+	e := newTestEnforcer(t, objFirstModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	version, err := e.PermissionsVersion(ctx, "alice")
+	if err != nil {
+		t.Fatalf("PermissionsVersion err: %v", err)
+	}
+	if _, err := e.AddPolicy(ctx, "data1", "alice", "read"); err != nil {
+		t.Fatalf("AddPolicy err: %v", err)
+	}
+	current, err := e.PermissionsVersion(ctx, "alice")
+	if err != nil || current == version {
+		t.Errorf("PermissionsVersion = %s, %v, supposed to change with the rules of alice", current, err)
+	}
+	if current != HashRules([]Rule{NewRule("p", "data1", "alice", "read")}) {
+		t.Errorf("PermissionsVersion does not hash the rules of alice")
+	}
+}
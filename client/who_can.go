@@ -0,0 +1,140 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	"github.com/casbin/casbin/v2"
+)
+
+// WhoCan returns the users allowed to perform act on obj, sorted. The candidates are the subjects of the
+// authorization rules and the users of the "g" rules, excluding the roles.
+//
+// The decisions are made by a local copy of the policy evaluated with Config.ModelText, so they account for
+// role inheritance, the matching functions of the matcher such as keyMatch, and deny rules, without one request
+// per candidate. WhoCan returns ErrUnknownModel if the enforcer was created without a model, and fails if the
+// requests of the model are not made of a subject, an object and an action.
+func (e *Enforcer) WhoCan(ctx context.Context, obj, act string) ([]string, error) {
+	local, err := e.localSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subjects, err := local.GetAllSubjects()
+	if err != nil {
+		return nil, err
+	}
+	grouping, err := local.GetGroupingPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[string]bool)
+	roles := make(map[string]bool)
+	for _, subject := range subjects {
+		candidates[subject] = true
+	}
+	for _, rule := range grouping {
+		candidates[rule[0]] = true
+		roles[rule[1]] = true
+	}
+
+	users := []string{}
+	for _, user := range sortedKeys(candidates) {
+		if roles[user] {
+			continue
+		}
+		allowed, err := local.Enforce(user, obj, act)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+// WhatCan returns the objects and actions sub is allowed, as {obj, act} pairs sorted by object and action.
+// The candidate objects are those of the authorization rules, patterns included, and the members and groups
+// of the resource role inheritance rules such as "g2"; the candidate actions are those of the authorization rules.
+// The decisions are made like WhoCan.
+func (e *Enforcer) WhatCan(ctx context.Context, sub string) ([][]string, error) {
+	local, err := e.localSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := local.GetAllObjects()
+	if err != nil {
+		return nil, err
+	}
+	actions, err := local.GetAllActions()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[string]bool)
+	for _, obj := range objects {
+		candidates[obj] = true
+	}
+	for ptype := range local.GetModel()["g"] {
+		if ptype == "g" {
+			continue
+		}
+		rules, err := local.GetNamedGroupingPolicy(ptype)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			candidates[rule[0]] = true
+			candidates[rule[1]] = true
+		}
+	}
+	uniqueActions := make(map[string]bool)
+	for _, act := range actions {
+		uniqueActions[act] = true
+	}
+
+	permissions := [][]string{}
+	for _, obj := range sortedKeys(candidates) {
+		for _, act := range sortedKeys(uniqueActions) {
+			allowed, err := local.Enforce(sub, obj, act)
+			if err != nil {
+				return nil, err
+			}
+			if allowed {
+				permissions = append(permissions, []string{obj, act})
+			}
+		}
+	}
+	return permissions, nil
+}
+
+// localSnapshot returns a local enforcer holding the current rules of every ptype,
+// whose requests are made of a subject, an object and an action.
+func (e *Enforcer) localSnapshot(ctx context.Context) (*casbin.Enforcer, error) {
+	rules, err := e.getRules(ctx, e.ptypes())
+	if err != nil {
+		return nil, err
+	}
+	local, err := e.localEnforcer(rules)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireSubObjAct(local); err != nil {
+		return nil, err
+	}
+	return local, nil
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const rbacWithDenyModelText = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act, eft
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow)) && !some(where (p.eft == deny))
+
+[matchers]
+m = g(r.sub, p.sub) && keyMatch(r.obj, p.obj) && r.act == p.act
+`
+
+func TestWhoCanWhatCan(t *testing.T) {
+	e := newTestEnforcer(t, rbacWithDenyModelText)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := e.AddRules(ctx, []Rule{
+		NewRule("p", "reader", "/data/*", "read", "allow"),
+		NewRule("p", "bob", "/data/secret", "read", "deny"),
+		NewRule("p", "carol", "/reports", "read", "allow"),
+		NewRule("g", "alice", "reader"),
+		NewRule("g", "bob", "reader"),
+	}); err != nil {
+		t.Fatalf("AddRules err: %v", err)
+	}
+
+	tests := []struct {
+		obj   string
+		users []string
+	}{
+		{"/data/1", []string{"alice", "bob"}},
+		{"/data/secret", []string{"alice"}},
+		{"/reports", []string{"carol"}},
+	}
+	for _, test := range tests {
+		users, err := e.WhoCan(ctx, test.obj, "read")
+		if err != nil {
+			t.Fatalf("WhoCan err: %v", err)
+		}
+		if !reflect.DeepEqual(users, test.users) {
+			t.Errorf("WhoCan(%q, read) = %v, supposed to be %v", test.obj, users, test.users)
+		}
+	}
+
+	permissions, err := e.WhatCan(ctx, "bob")
+	if err != nil {
+		t.Fatalf("WhatCan err: %v", err)
+	}
+	if want := [][]string{{"/data/*", "read"}}; !reflect.DeepEqual(permissions, want) {
+		t.Errorf("WhatCan(bob) = %v, supposed to be %v", permissions, want)
+	}
+}
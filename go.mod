@@ -5,13 +5,14 @@ go 1.19
 require (
 	github.com/casbin/casbin-server v1.17.0
 	github.com/casbin/casbin/v2 v2.100.0
+	github.com/casbin/govaluate v1.2.0
 	google.golang.org/grpc v1.42.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/bmatcuk/doublestar/v4 v4.6.1 // indirect
 	github.com/casbin/gorm-adapter/v3 v3.14.0 // indirect
-	github.com/casbin/govaluate v1.2.0 // indirect
 	github.com/casbin/mongodb-adapter/v3 v3.7.0 // indirect
 	github.com/glebarez/go-sqlite v1.19.1 // indirect
 	github.com/glebarez/sqlite v1.5.0 // indirect